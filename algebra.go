@@ -0,0 +1,165 @@
+package gasegment
+
+import "fmt"
+
+// CombineError is returned by Intersect, Union and Difference when the
+// combination can't be expressed as a single definition.
+type CombineError struct {
+	Op     string // "intersect", "union" or "difference"
+	Reason string
+}
+
+func (e *CombineError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Reason)
+}
+
+// Intersect returns the users or sessions matching both segments. Unlike
+// AddSegments the result is normalized. `gaid::` references can't be combined
+// with other segments; Expand them first.
+func (scs Segments) Intersect(other Segments) (Segments, error) {
+	return intersect("intersect", scs, other)
+}
+
+func intersect(op string, a, b Segments) (Segments, error) {
+	na, nb := a.Normalize(), b.Normalize()
+	switch {
+	case len(na) == 0:
+		return nb, nil
+	case len(nb) == 0, na.Equal(nb):
+		return na, nil
+	case hasReference(na) || hasReference(nb):
+		return nil, &CombineError{op, "gaid:: segment cannot be combined with other segments"}
+	}
+	return checkCombined(op, append(na, nb...).Normalize())
+}
+
+// Union returns the users or sessions matching either segment.
+//
+// Both segments are normalized and, unless one of them has all the segments of
+// the other, the union is distributed over their segments:
+// (a1;a2) or (b1) = (a1 or b1);(a2 or b1). Two condition segments of the same
+// scope are joined by distributing their OR groups, as each group matches if
+// any hit of the user or session matches. Other pairs, e.g. two sequences or a
+// users:: and a sessions:: condition, can't be joined unless they are the same,
+// and a *CombineError is returned. So is a condition with dateOfSession, which
+// can't be ORed with other expressions.
+func (scs Segments) Union(other Segments) (Segments, error) {
+	return union("union", scs, other)
+}
+
+func union(op string, a, b Segments) (Segments, error) {
+	na, nb := a.Normalize(), b.Normalize()
+	switch {
+	case len(na) == 0 || len(nb) == 0:
+		// one of them matches everything
+		return Segments{}, nil
+	case contains(na, nb):
+		// (b1;b2) or (b1) = (b1)
+		return nb, nil
+	case contains(nb, na):
+		return na, nil
+	}
+	var result Segments
+	for _, sa := range na {
+		for _, sb := range nb {
+			sg, err := unionSegment(op, sa, sb)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sg)
+		}
+	}
+	return checkCombined(op, result.Normalize())
+}
+
+func unionSegment(op string, a, b Segment) (Segment, error) {
+	if a.DefString() == b.DefString() {
+		return a, nil
+	}
+	if a.Type == ReferenceSegment || b.Type == ReferenceSegment {
+		return Segment{}, &CombineError{op, "gaid:: segment cannot be combined with other segments"}
+	}
+	if a.Scope != b.Scope {
+		return Segment{}, &CombineError{op, fmt.Sprintf("OR across %s and %s segments: %s, %s", a.Scope, b.Scope, a.DefString(), b.DefString())}
+	}
+	if a.Type == SequenceSegment || b.Type == SequenceSegment {
+		return Segment{}, &CombineError{op, fmt.Sprintf("OR across %s sequences: %s, %s", a.Scope, a.DefString(), b.DefString())}
+	}
+	if a.Condition.Exclude || b.Condition.Exclude {
+		return Segment{}, &CombineError{op, fmt.Sprintf("OR across excluded conditions: %s, %s", a.DefString(), b.DefString())}
+	}
+
+	ga, gb := a.Condition.AndExpression, b.Condition.AndExpression
+	if a.Condition.DateRange != nil || b.Condition.DateRange != nil {
+		return Segment{}, &CombineError{op, fmt.Sprintf("OR across conditions with dateOfSession: %s, %s", a.DefString(), b.DefString())}
+	}
+	if len(ga)*len(gb) > maxDistributedGroups {
+		return Segment{}, &CombineError{op, fmt.Sprintf("OR across %s and %s has more than %d groups", a.DefString(), b.DefString(), maxDistributedGroups)}
+	}
+	// (a1;a2) or (b1) = a1,b1;a2,b1
+	ae := make(AndExpression, 0, len(ga)*len(gb))
+	for _, oa := range ga {
+		for _, ob := range gb {
+			ae = append(ae, append(append(OrExpression{}, oa...), ob...))
+		}
+	}
+	return Segment{Scope: a.Scope, Type: ConditionSegment, Condition: Condition{AndExpression: ae}}, nil
+}
+
+// Difference returns the users or sessions matching the segments but not the
+// other ones, that is the intersection with the negation of each of the other
+// segments (see Segment.Negate) joined as in Union.
+func (scs Segments) Difference(other Segments) (Segments, error) {
+	nb := other.Normalize()
+	if len(nb) == 0 {
+		return nil, &CombineError{"difference", "the other segments match everything"}
+	}
+	var not Segments
+	for i, sg := range nb {
+		n, ok := sg.Negate()
+		if !ok {
+			return nil, &CombineError{"difference", fmt.Sprintf("%s cannot be negated", sg.DefString())}
+		}
+		if i == 0 {
+			not = Segments{n}
+			continue
+		}
+		var err error
+		if not, err = union("difference", not, Segments{n}); err != nil {
+			return nil, err
+		}
+	}
+	return intersect("difference", scs, not)
+}
+
+// checkCombined runs the structural checks of the parser on the result of op,
+// so that a combination never returns a definition Parse would reject.
+func checkCombined(op string, scs Segments) (Segments, error) {
+	if err := checkStructure(scs); err != nil {
+		return nil, &CombineError{op, err.Error()}
+	}
+	return scs, nil
+}
+
+// contains reports whether every segment of sub is in scs.
+func contains(scs, sub Segments) bool {
+	defs := map[string]bool{}
+	for _, sg := range scs {
+		defs[sg.DefString()] = true
+	}
+	for _, sg := range sub {
+		if !defs[sg.DefString()] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasReference(scs Segments) bool {
+	for _, sg := range scs {
+		if sg.Type == ReferenceSegment {
+			return true
+		}
+	}
+	return false
+}
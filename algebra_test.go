@@ -0,0 +1,103 @@
+package gasegment
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSegmentsAlgebra(t *testing.T) {
+	table := []struct {
+		op       string
+		a, b     string
+		expected string // "error: ..." for a *CombineError
+	}{
+		{"intersect", "users::condition::ga:pagePath==/a", "users::condition::ga:browser==Chrome", "users::condition::ga:browser==Chrome;ga:pagePath==/a"},
+		{"intersect", "users::condition::ga:pagePath==/a", "sessions::sequence::ga:pagePath==/b", "users::condition::ga:pagePath==/a;sessions::sequence::ga:pagePath==/b"},
+		{"intersect", "", "sessions::condition::ga:pagePath==/b", "sessions::condition::ga:pagePath==/b"},
+		{"intersect", "gaid::-1", "gaid::-1", "gaid::-1"},
+		{"intersect", "gaid::-1", "users::condition::ga:pagePath==/a", "error: intersect: gaid:: segment cannot be combined with other segments"},
+		{"intersect", "users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1", "users::condition::dateOfSession<>2014-05-01_2014-05-10", "users::condition::dateOfSession<>2014-05-01_2014-05-10;condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1"},
+
+		{"union", "users::condition::ga:pagePath==/a", "users::condition::ga:pagePath==/b", "users::condition::ga:pagePath==/a,ga:pagePath==/b"},
+		{"union", "users::condition::ga:pagePath==/a;ga:hits>1", "users::condition::ga:pagePath==/b", "users::condition::ga:hits>1,ga:pagePath==/b;ga:pagePath==/a,ga:pagePath==/b"},
+		{"union", "sessions::condition::ga:pagePath==/a;users::condition::ga:hits>1", "sessions::condition::ga:pagePath==/a", "sessions::condition::ga:pagePath==/a"},
+		{"union", "users::sequence::ga:pagePath==/a", "users::sequence::ga:pagePath==/a", "users::sequence::ga:pagePath==/a"},
+		{"union", "", "users::sequence::ga:pagePath==/a", ""},
+		{"union", "users::sequence::ga:pagePath==/a", "users::sequence::ga:pagePath==/b", "error: union: OR across users:: sequences: users::sequence::ga:pagePath==/a, users::sequence::ga:pagePath==/b"},
+		{"union", "users::condition::ga:pagePath==/a", "sessions::condition::ga:pagePath==/b", "error: union: OR across users:: and sessions:: segments: users::condition::ga:pagePath==/a, sessions::condition::ga:pagePath==/b"},
+		{"union", "users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1", "users::condition::ga:pagePath==/b", "error: union: OR across conditions with dateOfSession: users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1, users::condition::ga:pagePath==/b"},
+		{"union", "users::condition::dateOfSession<>2014-05-20_2014-05-30;sessions::condition::ga:hits>1", "users::condition::dateOfSession<>2014-05-20_2014-05-30", "users::condition::dateOfSession<>2014-05-20_2014-05-30"},
+		{"union", "users::condition::!ga:pagePath==/a", "users::condition::ga:pagePath==/b", "error: union: OR across excluded conditions: users::condition::!ga:pagePath==/a, users::condition::ga:pagePath==/b"},
+
+		{"difference", "users::condition::ga:pagePath==/a", "users::condition::ga:pagePath==/b", "users::condition::!ga:pagePath==/b;condition::ga:pagePath==/a"},
+		{"difference", "users::condition::ga:pagePath==/a", "users::condition::perUser::ga:sessions>5", "users::condition::ga:pagePath==/a;perUser::ga:sessions<=5"},
+		{"difference", "sessions::condition::ga:pagePath==/a", "sessions::sequence::ga:pagePath==/b;->>ga:pagePath==/c", "sessions::condition::ga:pagePath==/a;sequence::!ga:pagePath==/b;->>ga:pagePath==/c"},
+		{"difference", "users::condition::ga:pagePath==/a", "users::condition::perUser::ga:sessions>5;sessions::condition::perSession::ga:hits>1", "error: difference: OR across users:: and sessions:: segments: users::condition::perUser::ga:sessions<=5, sessions::condition::perSession::ga:hits<=1"},
+		{"difference", "users::condition::ga:pagePath==/a", "", "error: difference: the other segments match everything"},
+	}
+	for _, c := range table {
+		parse := func(def string) Segments {
+			if def == "" {
+				return Segments{}
+			}
+			return MustParse(def)
+		}
+		a, b := parse(c.a), parse(c.b)
+		var act Segments
+		var err error
+		switch c.op {
+		case "intersect":
+			act, err = a.Intersect(b)
+		case "union":
+			act, err = a.Union(b)
+		case "difference":
+			act, err = a.Difference(b)
+		}
+		if strings.HasPrefix(c.expected, "error: ") {
+			if _, ok := err.(*CombineError); !ok || err.Error() != strings.TrimPrefix(c.expected, "error: ") {
+				t.Errorf("%s(%s, %s): unexpected error %v", c.op, c.a, c.b, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s(%s, %s): %v", c.op, c.a, c.b, err)
+			continue
+		}
+		if def := act.DefString(); def != c.expected {
+			t.Errorf("%s(%s, %s)\n\texpected: %s\n\tactual:   %s", c.op, c.a, c.b, c.expected, def)
+		}
+	}
+}
+
+func TestUnionProperty(t *testing.T) {
+	// a condition matches a session if each OR group matches some hit of the session
+	matchSession := func(session []hit, a AndExpression) bool {
+		for _, or := range a {
+			matched := false
+			for _, h := range session {
+				matched = matched || h.matchOr(or)
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	}
+	condition := func(a AndExpression) Segments {
+		return Segments{{Scope: SessionScope, Type: ConditionSegment, Condition: Condition{AndExpression: a}}}
+	}
+	r := newRand()
+	for n := 0; n < 300; n++ {
+		a, b := randomAndExpression(r), randomAndExpression(r)
+		u, err := condition(a).Union(condition(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for m := 0; m < 50; m++ {
+			session := []hit{randomHit(r), randomHit(r)}
+			if matchSession(session, u[0].Condition.AndExpression) != (matchSession(session, a) || matchSession(session, b)) {
+				t.Errorf("%v: %s or %s => %s", session, a.DefString(), b.DefString(), u.DefString())
+			}
+		}
+	}
+}
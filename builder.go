@@ -0,0 +1,371 @@
+package gasegment
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Target is a dimension or a metric to build an expression on. See Dim and Metric.
+type Target struct {
+	kind        string // "DIMENSION" or "METRIC"
+	target      DimensionOrMetric
+	metricScope MetricScope
+}
+
+// Dim returns a dimension target such as `ga:pagePath`.
+func Dim(name string) Target {
+	return Target{kind: "DIMENSION", target: DimensionOrMetric(name)}
+}
+
+// Metric returns a metric target such as `ga:sessions`. The metric scope can be
+// set with PerHit, PerSession, PerUser and PerProduct.
+func Metric(name string) Target {
+	return Target{kind: "METRIC", target: DimensionOrMetric(name)}
+}
+
+func (t Target) PerHit() Target {
+	return t.scoped(PerHit)
+}
+
+func (t Target) PerSession() Target {
+	return t.scoped(PerSession)
+}
+
+func (t Target) PerUser() Target {
+	return t.scoped(PerUser)
+}
+
+func (t Target) PerProduct() Target {
+	return t.scoped(PerProduct)
+}
+
+func (t Target) scoped(ms MetricScope) Target {
+	t.metricScope = ms
+	return t
+}
+
+// Cond is an expression built from a Target, or the error found while building it.
+type Cond struct {
+	Expression Expression
+	Err        error
+}
+
+func (t Target) cond(e Expression) Cond {
+	e.MetricScope = t.metricScope
+	return Cond{Expression: e, Err: t.validate(e)}
+}
+
+func (t Target) validate(e Expression) error {
+	ca, err := GetDimensionOrMetricAttributes(t.target.String())
+	if err != nil {
+		return fmt.Errorf("%s: %w", t.target, err)
+	}
+	if ca.Type != t.kind {
+		return fmt.Errorf("%s: not a %s", t.target, map[string]string{"DIMENSION": "dimension", "METRIC": "metric"}[t.kind])
+	}
+	if !ca.AllowedInSegments {
+		return fmt.Errorf("%s: not allowed in segments", t.target)
+	}
+	if err := ValidateExpression(e); err != nil {
+		return err
+	}
+	switch e.Operator {
+	case Regexp, NotRegexp:
+		if _, err := regexp.Compile(e.Value); err != nil {
+			return &ValueError{e, err.Error()}
+		}
+	case InList, NotInList:
+		if _, err := e.List(); err != nil {
+			return err
+		}
+	case Between, NotBetween:
+		if t.kind == "METRIC" {
+			if _, _, err := e.NumberRange(); err != nil {
+				return err
+			}
+		} else if _, _, err := e.Range(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t Target) Eq(v string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: Equal, Value: v})
+}
+
+func (t Target) Ne(v string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: NotEqual, Value: v})
+}
+
+func (t Target) Contains(v string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: ContainsSubstring, Value: v})
+}
+
+func (t Target) NotContains(v string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: NotContainsSubstring, Value: v})
+}
+
+func (t Target) Matches(re string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: Regexp, Value: re})
+}
+
+func (t Target) NotMatches(re string) Cond {
+	return t.cond(Expression{Target: t.target, Operator: NotRegexp, Value: re})
+}
+
+// In matches any of the items (`[]`). Items are escaped as needed.
+func (t Target) In(items ...string) Cond {
+	return t.cond(NewInList(t.target, items...))
+}
+
+// NotIn matches none of the items (`![]`).
+func (t Target) NotIn(items ...string) Cond {
+	e := NewInList(t.target, items...)
+	e.Operator = NotInList
+	return t.cond(e)
+}
+
+func (t Target) Lt(v float64) Cond {
+	return t.cond(NewComparison(t.target, LessThan, v))
+}
+
+func (t Target) Le(v float64) Cond {
+	return t.cond(NewComparison(t.target, LessThanEqual, v))
+}
+
+func (t Target) Gt(v float64) Cond {
+	return t.cond(NewComparison(t.target, GreaterThan, v))
+}
+
+func (t Target) Ge(v float64) Cond {
+	return t.cond(NewComparison(t.target, GreaterThanEqual, v))
+}
+
+// Between matches the numbers greater than min and less than max (`<>`); the
+// bounds of a metric range are exclusive.
+func (t Target) Between(min, max float64) Cond {
+	return t.cond(NewNumberBetween(t.target, min, max))
+}
+
+// NotBetween matches the numbers not matched by Between (`!<>`).
+func (t Target) NotBetween(min, max float64) Cond {
+	e := NewNumberBetween(t.target, min, max)
+	e.Operator = NotBetween
+	return t.cond(e)
+}
+
+// BetweenStrings matches the dimension values from min to max (`<>`).
+func (t Target) BetweenStrings(min, max string) Cond {
+	return t.cond(NewBetween(t.target, min, max))
+}
+
+// DateOfSessionBetween restricts the segment to the sessions from start to end (`dateOfSession<>`).
+func DateOfSessionBetween(start, end time.Time) Cond {
+	dr := DateRange{Start: start, End: end}
+	return Cond{Expression: NewDateOfSession(start, end), Err: dr.Validate()}
+}
+
+// Builder builds Segments with a fluent API, e.g.
+//
+//	Users().Condition().Where(Dim("ga:pagePath").Eq("/a")).Or(Dim("ga:pagePath").Eq("/b")).
+//		Sessions().Sequence().Step(Dim("ga:pagePath").Eq("/c")).ThenImmediately(Dim("ga:pagePath").Eq("/d")).
+//		Build()
+//
+// The first error is kept and returned by Build; later calls are ignored.
+type Builder struct {
+	scope    SegmentScope
+	segments Segments
+	err      error
+}
+
+// Users starts building segments of users:: scope.
+func Users() *Builder {
+	return &Builder{scope: UserScope}
+}
+
+// Sessions starts building segments of sessions:: scope.
+func Sessions() *Builder {
+	return &Builder{scope: SessionScope}
+}
+
+// Users sets the scope of the following segments to users::.
+func (b *Builder) Users() *Builder {
+	b.scope = UserScope
+	return b
+}
+
+// Sessions sets the scope of the following segments to sessions::.
+func (b *Builder) Sessions() *Builder {
+	b.scope = SessionScope
+	return b
+}
+
+// Condition starts a condition segment.
+func (b *Builder) Condition() *ConditionBuilder {
+	b.segments = append(b.segments, Segment{Scope: b.scope, Type: ConditionSegment})
+	return &ConditionBuilder{b}
+}
+
+// Sequence starts a sequence segment.
+func (b *Builder) Sequence() *SequenceBuilder {
+	b.segments = append(b.segments, Segment{Scope: b.scope, Type: SequenceSegment})
+	return &SequenceBuilder{b}
+}
+
+// Build returns the segments or the first error. The segments are checked like
+// Parse checks a definition, e.g. dateOfSession is only allowed as the first
+// condition of a segment, where it is set as the DateRange.
+func (b *Builder) Build() (Segments, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.segments) == 0 {
+		return nil, errors.New("no segment")
+	}
+	for _, sg := range b.segments {
+		switch {
+		case sg.Type == ConditionSegment && len(sg.Condition.AndExpression) == 0:
+			return nil, fmt.Errorf("%scondition has no expression", sg.Scope)
+		case sg.Type == SequenceSegment && len(sg.Sequence.SequenceSteps) == 0:
+			return nil, fmt.Errorf("%ssequence has no step", sg.Scope)
+		}
+	}
+	scs := b.segments.Clone()
+	for i := range scs {
+		switch sg := &scs[i]; sg.Type {
+		case ConditionSegment:
+			sg.Condition.DateRange, sg.Condition.AndExpression = leadingDateRange(sg.Condition.AndExpression)
+		case SequenceSegment:
+			step := &sg.Sequence.SequenceSteps[0]
+			step.DateRange, step.AndExpression = leadingDateRange(step.AndExpression)
+		}
+	}
+	// e.g. a misplaced dateOfSession
+	if err := checkStructure(scs); err != nil {
+		return nil, err
+	}
+	return scs, nil
+}
+
+func (b *Builder) last() *Segment {
+	return &b.segments[len(b.segments)-1]
+}
+
+// add appends c to the last OR group of ae, or to a new group.
+func (b *Builder) add(ae *AndExpression, c Cond, newGroup bool) {
+	if b.err != nil {
+		return
+	}
+	if c.Err != nil {
+		b.err = c.Err
+		return
+	}
+	if newGroup || len(*ae) == 0 {
+		*ae = append(*ae, OrExpression{c.Expression})
+		return
+	}
+	last := &(*ae)[len(*ae)-1]
+	*last = append(*last, c.Expression)
+}
+
+// ConditionBuilder builds a condition segment.
+type ConditionBuilder struct {
+	*Builder
+}
+
+// Where adds an AND group with c.
+func (cb *ConditionBuilder) Where(c Cond) *ConditionBuilder {
+	cb.add(&cb.last().Condition.AndExpression, c, true)
+	return cb
+}
+
+// And adds an AND group with c, like Where.
+func (cb *ConditionBuilder) And(c Cond) *ConditionBuilder {
+	return cb.Where(c)
+}
+
+// Or adds c to the last AND group.
+func (cb *ConditionBuilder) Or(c Cond) *ConditionBuilder {
+	cb.add(&cb.last().Condition.AndExpression, c, false)
+	return cb
+}
+
+// Exclude makes the condition exclude the matching users or sessions (`condition::!`).
+func (cb *ConditionBuilder) Exclude() *ConditionBuilder {
+	cb.last().Condition.Exclude = true
+	return cb
+}
+
+// SequenceBuilder builds a sequence segment.
+type SequenceBuilder struct {
+	*Builder
+}
+
+func (sb *SequenceBuilder) step(t SequenceStepType, c Cond) *SequenceBuilder {
+	seq := &sb.last().Sequence
+	if sb.err == nil && (t == FirstStep) != (len(seq.SequenceSteps) == 0) {
+		if t == FirstStep {
+			sb.err = errors.New("sequence already has a first step; use Then or ThenImmediately")
+		} else {
+			sb.err = errors.New("sequence must start with Step")
+		}
+	}
+	if sb.err != nil {
+		return sb
+	}
+	seq.SequenceSteps = append(seq.SequenceSteps, SequenceStep{Type: t})
+	sb.add(&seq.SequenceSteps[len(seq.SequenceSteps)-1].AndExpression, c, true)
+	return sb
+}
+
+// Step adds the first step.
+func (sb *SequenceBuilder) Step(c Cond) *SequenceBuilder {
+	return sb.step(FirstStep, c)
+}
+
+// Then adds a step following the previous one (`;->>`).
+func (sb *SequenceBuilder) Then(c Cond) *SequenceBuilder {
+	return sb.step(Precedes, c)
+}
+
+// ThenImmediately adds a step immediately following the previous one (`;->`).
+func (sb *SequenceBuilder) ThenImmediately(c Cond) *SequenceBuilder {
+	return sb.step(ImmediatelyPrecedes, c)
+}
+
+// And adds an AND group with c to the last step.
+func (sb *SequenceBuilder) And(c Cond) *SequenceBuilder {
+	return sb.addToStep(c, true)
+}
+
+// Or adds c to the last AND group of the last step.
+func (sb *SequenceBuilder) Or(c Cond) *SequenceBuilder {
+	return sb.addToStep(c, false)
+}
+
+func (sb *SequenceBuilder) addToStep(c Cond, newGroup bool) *SequenceBuilder {
+	steps := sb.last().Sequence.SequenceSteps
+	if len(steps) == 0 {
+		if sb.err == nil {
+			sb.err = errors.New("sequence must start with Step")
+		}
+		return sb
+	}
+	sb.add(&steps[len(steps)-1].AndExpression, c, newGroup)
+	return sb
+}
+
+// FirstHit requires the first step to match the first hit (`sequence::^`).
+func (sb *SequenceBuilder) FirstHit() *SequenceBuilder {
+	sb.last().Sequence.FirstHitMatchesFirstStep = true
+	return sb
+}
+
+// Not excludes the users or sessions matching the sequence (`sequence::!`).
+func (sb *SequenceBuilder) Not() *SequenceBuilder {
+	sb.last().Sequence.Not = true
+	return sb
+}
@@ -0,0 +1,97 @@
+package gasegment
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	table := []struct {
+		builder  interface{ Build() (Segments, error) }
+		expected string
+	}{
+		{
+			Users().Condition().Where(Dim("ga:pagePath").Eq("/a")).Or(Dim("ga:pagePath").Eq("/b")),
+			"users::condition::ga:pagePath==/a,ga:pagePath==/b",
+		},
+		{
+			Users().Condition().Where(Dim("ga:pagePath").Contains("a;b,c")).And(Metric("ga:sessions").PerUser().Gt(5)),
+			`users::condition::ga:pagePath=@a\;b\,c;perUser::ga:sessions>5`,
+		},
+		{
+			Sessions().Condition().Exclude().Where(Dim("ga:pagePath").In("/a|b", "/c")).And(Metric("ga:hits").Between(1, 2.5)),
+			`sessions::condition::!ga:pagePath[]/a\|b|/c;ga:hits<>1_2.5`,
+		},
+		{
+			Sessions().Sequence().Step(Dim("ga:pagePath").Eq("/a")).ThenImmediately(Dim("ga:pagePath").Eq("/b")).Or(Dim("ga:pagePath").Eq("/c")),
+			"sessions::sequence::ga:pagePath==/a;->ga:pagePath==/b,ga:pagePath==/c",
+		},
+		{
+			Users().Sequence().Not().FirstHit().Step(Dim("ga:pagePath").Matches("^/a$")).And(Dim("ga:browser").Ne("Chrome")).Then(Metric("ga:hits").PerHit().Ge(2)).
+				Sessions().Condition().Where(DateOfSessionBetween(time.Date(2014, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2014, 5, 30, 0, 0, 0, 0, time.UTC))),
+			"users::sequence::!^ga:pagePath=~^/a$;ga:browser!=Chrome;->>perHit::ga:hits>=2;sessions::condition::dateOfSession<>2014-05-20_2014-05-30",
+		},
+	}
+	for _, c := range table {
+		ss, err := c.builder.Build()
+		if err != nil {
+			t.Errorf("%s: %v", c.expected, err)
+			continue
+		}
+		if def := ss.DefString(); def != c.expected {
+			t.Errorf("expected: %s\n\tactual:   %s", c.expected, def)
+		}
+		if _, err := Parse(ss.DefString()); err != nil {
+			t.Errorf("%s: %v", c.expected, err)
+		}
+	}
+}
+
+func TestBuilderError(t *testing.T) {
+	start := time.Date(2014, 5, 20, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2014, 5, 30, 0, 0, 0, 0, time.UTC)
+	table := []struct {
+		builder  interface{ Build() (Segments, error) }
+		expected string
+	}{
+		{Users(), "no segment"},
+		{Users().Condition(), "users::condition has no expression"},
+		{Sessions().Sequence(), "sessions::sequence has no step"},
+		{Users().Condition().Where(Dim("ga:unknown").Eq("a")), "ga:unknown: no such dimension or metric"},
+		{Users().Condition().Where(Dim("ga:sessions").Eq("1")), "ga:sessions: not a dimension"},
+		{Users().Condition().Where(Metric("ga:users").Gt(1)), "ga:users: not allowed in segments"},
+		{Users().Condition().Where(Metric("ga:hits").PerProduct().Gt(1)), "metric ga:hits is not product scoped"},
+		{Users().Condition().Where(Dim("ga:pagePath").Matches("(")), "ga:pagePath=~(: error parsing regexp: missing closing ): `(`"},
+		{Users().Condition().Where(Dim("ga:pagePath").In()), "ga:pagePath[]: empty list"},
+		{Users().Condition().Where(Metric("ga:hits").Between(2, 1)), "ga:hits<>2_1: min value is greater than max value"},
+		{Users().Sequence().Then(Dim("ga:pagePath").Eq("/a")), "sequence must start with Step"},
+		{Users().Sequence().Step(Dim("ga:pagePath").Eq("/a")).Step(Dim("ga:pagePath").Eq("/b")), "sequence already has a first step; use Then or ThenImmediately"},
+		{
+			Users().Sequence().Step(Dim("ga:pagePath").Eq("/a")).Then(DateOfSessionBetween(start, end)),
+			"segments[0].sequence.steps[1].and[0].or[0]: dateOfSession is only allowed in the first step of a sequence",
+		},
+		{
+			Users().Condition().Where(DateOfSessionBetween(start, end)).And(DateOfSessionBetween(start, end)),
+			"segments[0].condition.and[0].or[0]: dateOfSession is only allowed as the first condition of a segment",
+		},
+		{
+			Users().Condition().Where(Dim("ga:pagePath").Eq("/a")).And(DateOfSessionBetween(start, end)),
+			"segments[0].condition.and[1].or[0]: dateOfSession is only allowed as the first condition of a segment",
+		},
+		{
+			Users().Condition().Where(Dim("ga:pagePath").Eq("/a")).Or(DateOfSessionBetween(start, end)),
+			"segments[0].condition.and[0].or[1]: dateOfSession cannot be combined with other expressions by OR",
+		},
+		{
+			// the first error is kept
+			Users().Condition().Where(Dim("ga:unknown").Eq("a")).Or(Dim("ga:sessions").Eq("1")),
+			"ga:unknown: no such dimension or metric",
+		},
+	}
+	for _, c := range table {
+		_, err := c.builder.Build()
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("expected error %q, but %v", c.expected, err)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -13,6 +15,8 @@ import (
 	"google.golang.org/api/analyticsreporting/v4"
 )
 
+var limits = flag.String("limits", "", "fail if the segment exceeds the limits of GA: standard or 360")
+
 func parse(reader io.Reader) (*analyticsreporting.DynamicSegment, error) {
 	b, err := ioutil.ReadAll(reader)
 	if err != nil {
@@ -23,6 +27,9 @@ func parse(reader io.Reader) (*analyticsreporting.DynamicSegment, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkLimits(segments); err != nil {
+		return nil, err
+	}
 	ds, err := supportv4.TransformSegments(&segments)
 	if err != nil {
 		return nil, err
@@ -30,6 +37,28 @@ func parse(reader io.Reader) (*analyticsreporting.DynamicSegment, error) {
 	return ds, nil
 }
 
+func checkLimits(segments gasegment.Segments) error {
+	var l gasegment.Limits
+	switch *limits {
+	case "":
+		return nil
+	case "standard":
+		l = gasegment.StandardLimits
+	case "360":
+		l = gasegment.GA360Limits
+	default:
+		return fmt.Errorf("unknown limits %q", *limits)
+	}
+	vs := l.Check(segments)
+	if len(vs) == 0 {
+		return nil
+	}
+	for _, v := range vs {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	return fmt.Errorf("%d limit violation(s)", len(vs))
+}
+
 func dump(ds *analyticsreporting.DynamicSegment) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -37,14 +66,15 @@ func dump(ds *analyticsreporting.DynamicSegment) error {
 }
 
 func main() {
-	if len(os.Args) < 2 {
+	flag.Parse()
+	if flag.NArg() == 0 {
 		ds, err := parse(os.Stdin)
 		if err != nil {
 			log.Fatal(err)
 		}
 		dump(ds)
 	} else {
-		for _, fname := range os.Args[1:] {
+		for _, fname := range flag.Args() {
 			f, err := os.Open(fname)
 			defer f.Close()
 			if err != nil {
@@ -0,0 +1,174 @@
+package gasegment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DateOfSession is the synthetic dimension used to restrict a segment to sessions within a date range,
+// e.g. `dateOfSession<>2014-05-20_2014-05-30`.
+const DateOfSession = DimensionOrMetric("dateOfSession")
+
+const (
+	DateOfSessionLayout  = "2006-01-02"
+	MaxDateOfSessionDays = 31
+)
+
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Days returns the number of calendar days in the range, both ends inclusive.
+// The times of day are ignored, as they are in String.
+func (dr DateRange) Days() int {
+	return int(calendarDate(dr.End).Sub(calendarDate(dr.Start)).Hours()/24) + 1
+}
+
+// calendarDate returns the date of t in its location as midnight UTC, so that
+// days are 24 hours long.
+func calendarDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (dr DateRange) String() string {
+	return JoinRangeValue(dr.Start.Format(DateOfSessionLayout), dr.End.Format(DateOfSessionLayout))
+}
+
+func (dr DateRange) Validate() error {
+	if calendarDate(dr.End).Before(calendarDate(dr.Start)) {
+		return fmt.Errorf("dateOfSession: end date %s is before start date %s", dr.End.Format(DateOfSessionLayout), dr.Start.Format(DateOfSessionLayout))
+	}
+	if days := dr.Days(); days > MaxDateOfSessionDays {
+		return fmt.Errorf("dateOfSession: range of %d days exceeds the maximum of %d days", days, MaxDateOfSessionDays)
+	}
+	return nil
+}
+
+// ParseDateRange parses the `YYYY-MM-DD_YYYY-MM-DD` value of a dateOfSession expression.
+func ParseDateRange(s string) (DateRange, error) {
+	dr, err := parseDateRange(s)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if err := dr.Validate(); err != nil {
+		return DateRange{}, err
+	}
+	return dr, nil
+}
+
+// parseDateRange is ParseDateRange without Validate.
+func parseDateRange(s string) (DateRange, error) {
+	vs := SplitRangeValue(s)
+	if len(vs) != 2 {
+		return DateRange{}, fmt.Errorf("dateOfSession: required format is 'YYYY-MM-DD_YYYY-MM-DD', but %q", s)
+	}
+	start, err := time.Parse(DateOfSessionLayout, vs[0])
+	if err != nil {
+		return DateRange{}, fmt.Errorf("dateOfSession: invalid start date %q", vs[0])
+	}
+	end, err := time.Parse(DateOfSessionLayout, vs[1])
+	if err != nil {
+		return DateRange{}, fmt.Errorf("dateOfSession: invalid end date %q", vs[1])
+	}
+	return DateRange{Start: start, End: end}, nil
+}
+
+// NewDateOfSession creates a `dateOfSession<>start_end` expression.
+func NewDateOfSession(start, end time.Time) Expression {
+	return Expression{
+		Target:   DateOfSession,
+		Operator: Between,
+		Value:    DateRange{Start: start, End: end}.String(),
+	}
+}
+
+func (c Expression) IsDateOfSession() bool {
+	return c.Target == DateOfSession
+}
+
+// DateOfSession returns the typed date range of a dateOfSession expression.
+func (c Expression) DateOfSession() (DateRange, error) {
+	if !c.IsDateOfSession() {
+		return DateRange{}, errors.New("not a dateOfSession expression")
+	}
+	if c.MetricScope != Default || c.Operator != Between {
+		return DateRange{}, fmt.Errorf("dateOfSession: only %s is allowed, but %s%s", Between, c.MetricScope, c.Operator)
+	}
+	return ParseDateRange(c.Value)
+}
+
+// DateOfSession returns the date range constraint of the condition, if any.
+func (c Condition) DateOfSession() (DateRange, bool) {
+	if c.DateRange == nil {
+		return DateRange{}, false
+	}
+	return *c.DateRange, true
+}
+
+// DateOfSession returns the date range constraint of the first step, if any.
+func (s Sequence) DateOfSession() (DateRange, bool) {
+	if len(s.SequenceSteps) == 0 || s.SequenceSteps[0].DateRange == nil {
+		return DateRange{}, false
+	}
+	return *s.SequenceSteps[0].DateRange, true
+}
+
+// SetDateOfSession replaces the date range constraint of the condition.
+func (c *Condition) SetDateOfSession(dr DateRange) {
+	c.DateRange = &dr
+}
+
+// SetDateOfSession replaces the date range constraint of the first step.
+func (s *Sequence) SetDateOfSession(dr DateRange) {
+	if len(s.SequenceSteps) == 0 {
+		s.SequenceSteps = NewSequenceSteps(SequenceStep{Type: FirstStep})
+	}
+	steps := make(SequenceSteps, len(s.SequenceSteps))
+	copy(steps, s.SequenceSteps)
+	steps[0].DateRange = &dr
+	s.SequenceSteps = steps
+}
+
+// withDateRange returns a with the dateOfSession expression of dr, if any, as
+// its first group, as it is written in a definition.
+func withDateRange(dr *DateRange, a AndExpression) AndExpression {
+	if dr == nil {
+		return a
+	}
+	return append(AndExpression{{NewDateOfSession(dr.Start, dr.End)}}, a...)
+}
+
+// leadingDateRange takes a valid dateOfSession expression alone in the first
+// group of a out as a DateRange.
+func leadingDateRange(a AndExpression) (*DateRange, AndExpression) {
+	if len(a) == 0 || len(a[0]) != 1 || !a[0][0].IsDateOfSession() {
+		return nil, a
+	}
+	dr, err := a[0][0].DateOfSession()
+	if err != nil {
+		return nil, a
+	}
+	return &dr, a[1:]
+}
+
+// splitDateRange takes the dateOfSession expression of the first group of a
+// out as a DateRange. A dateOfSession anywhere else is an error.
+func splitDateRange(a AndExpression) (*DateRange, AndExpression, error) {
+	if len(a) > 0 && len(a[0]) == 1 && a[0][0].IsDateOfSession() {
+		if _, err := a[0][0].DateOfSession(); err != nil {
+			return nil, nil, err
+		}
+	}
+	dr, a := leadingDateRange(a)
+	for _, or := range a {
+		for _, e := range or {
+			if e.IsDateOfSession() {
+				return nil, nil, errors.New("dateOfSession is only allowed as the first condition of a segment")
+			}
+		}
+	}
+	return dr, a, nil
+}
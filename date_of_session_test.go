@@ -0,0 +1,133 @@
+package gasegment
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateOfSession(t *testing.T) {
+	start := time.Date(2014, 5, 20, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2014, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		definition string
+		get        func(Segments) (DateRange, bool)
+	}{
+		{
+			definition: "users::sequence::^dateOfSession<>2014-05-20_2014-05-30;ga:sessionCount==1;->>ga:sessionDurationBucket>600",
+			get:        func(ss Segments) (DateRange, bool) { return ss[0].Sequence.DateOfSession() },
+		},
+		{
+			definition: "users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:pagePath==/abc",
+			get:        func(ss Segments) (DateRange, bool) { return ss[0].Condition.DateOfSession() },
+		},
+	}
+	for _, c := range table {
+		ss, err := Parse(c.definition)
+		if err != nil {
+			t.Errorf("%s: %s", c.definition, err)
+			continue
+		}
+		dr, ok := c.get(ss)
+		if !ok {
+			t.Errorf("%s: dateOfSession not found", c.definition)
+			continue
+		}
+		if !dr.Start.Equal(start) || !dr.End.Equal(end) {
+			t.Errorf("%s: unexpected range %s", c.definition, dr)
+		}
+		if act := ss.DefString(); act != c.definition {
+			t.Errorf("check failed\n\texpected: %s\n\tactual:   %s", c.definition, act)
+		}
+	}
+}
+
+func TestDateRangeField(t *testing.T) {
+	ss := MustParse("users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:pagePath==/abc;sequence::dateOfSession<>2014-05-20_2014-05-30;->>ga:pagePath==/a")
+	c := ss[0].Condition
+	if c.DateRange == nil || c.DateRange.String() != "2014-05-20_2014-05-30" || c.AndExpression.DefString() != "ga:pagePath==/abc" {
+		t.Errorf("unexpected condition %+v", c)
+	}
+	step := ss[1].Sequence.SequenceSteps[0]
+	if step.DateRange == nil || step.DateRange.String() != "2014-05-20_2014-05-30" || len(step.AndExpression) != 0 {
+		t.Errorf("unexpected first step %+v", step)
+	}
+}
+
+func TestSetDateOfSession(t *testing.T) {
+	ss := MustParse("users::condition::ga:pagePath==/abc;sequence::ga:pagePath==/a;->>ga:pagePath==/b")
+	dr := DateRange{
+		Start: time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2014, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	ss[0].Condition.SetDateOfSession(dr)
+	ss[1].Sequence.SetDateOfSession(dr)
+
+	expected := "users::condition::dateOfSession<>2014-05-01_2014-05-31;ga:pagePath==/abc;sequence::dateOfSession<>2014-05-01_2014-05-31;ga:pagePath==/a;->>ga:pagePath==/b"
+	if act := ss.DefString(); act != expected {
+		t.Errorf("unexpected definition\n\texpected: %s\n\tactual:   %s", expected, act)
+	}
+	if _, err := Parse(expected); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDateRangeDays(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	table := []struct {
+		dr    DateRange
+		days  int
+		valid bool
+	}{
+		{DateRange{time.Date(2014, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2014, 5, 20, 0, 0, 0, 0, time.UTC)}, 1, true},
+		// the times of day are ignored
+		{DateRange{time.Date(2014, 3, 1, 12, 0, 0, 0, time.UTC), time.Date(2014, 4, 1, 0, 0, 0, 0, time.UTC)}, 32, false},
+		{DateRange{time.Date(2014, 3, 1, 12, 0, 0, 0, time.UTC), time.Date(2014, 3, 31, 23, 59, 0, 0, time.UTC)}, 31, true},
+		{DateRange{time.Date(2014, 5, 20, 23, 0, 0, 0, tokyo), time.Date(2014, 5, 20, 1, 0, 0, 0, tokyo)}, 1, true},
+		// 2014-03-09 has 23 hours in New York
+		{DateRange{time.Date(2014, 3, 1, 0, 0, 0, 0, newYork), time.Date(2014, 3, 31, 0, 0, 0, 0, newYork)}, 31, true},
+		{DateRange{time.Date(2014, 3, 1, 0, 0, 0, 0, newYork), time.Date(2014, 4, 1, 0, 0, 0, 0, newYork)}, 32, false},
+	}
+	for _, c := range table {
+		if days := c.dr.Days(); days != c.days {
+			t.Errorf("%s: expected %d days, but %d", c.dr, c.days, days)
+		}
+		err := c.dr.Validate()
+		if valid := err == nil; valid != c.valid {
+			t.Errorf("%s: unexpected error %v", c.dr, err)
+		}
+		// Validate agrees with the parser on the written range
+		if _, err := ParseDateRange(c.dr.String()); (err == nil) != c.valid {
+			t.Errorf("%s: unexpected parse error %v", c.dr, err)
+		}
+	}
+}
+
+func TestInvalidDateOfSession(t *testing.T) {
+	table := []string{
+		// format
+		"users::condition::dateOfSession<>2014-05-20",
+		"users::condition::dateOfSession<>2014-05-20_20140530",
+		"users::condition::dateOfSession==2014-05-20_2014-05-30",
+		"users::condition::perHit::dateOfSession<>2014-05-20_2014-05-30",
+		// range
+		"users::condition::dateOfSession<>2014-05-30_2014-05-20",
+		"users::condition::dateOfSession<>2014-05-01_2014-06-01",
+		// placement
+		"users::sequence::ga:sessionCount==1;->>dateOfSession<>2014-05-20_2014-05-30",
+		"users::condition::ga:sessionCount==1,dateOfSession<>2014-05-20_2014-05-30",
+		"users::condition::dateOfSession<>2014-05-20_2014-05-30,ga:sessionCount==1",
+		"users::condition::dateOfSession<>2014-05-20_2014-05-30;dateOfSession<>2014-05-20_2014-05-30",
+		"users::condition::ga:sessionCount==1;dateOfSession<>2014-05-20_2014-05-30",
+		"users::sequence::^ga:sessionCount==1;dateOfSession<>2014-05-20_2014-05-30;->>ga:sessionDurationBucket>600",
+	}
+	for _, def := range table {
+		if _, err := Parse(def); err == nil {
+			t.Errorf("parse '%s' must be error", def)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gasegment
+
+import "fmt"
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic is a positioned problem found by ParseWithDiagnostics.
+type Diagnostic struct {
+	Severity Severity
+	ParseError
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s at %d: %s", d.Severity, d.Offset, d.Message)
+}
+
+type Diagnostics []Diagnostic
+
+// HasErrors reports whether any diagnostic has error severity.
+func (ds Diagnostics) HasErrors() bool {
+	return ds.Err() != nil
+}
+
+// Err returns the first error as a *ParseError, or nil if there are only warnings.
+func (ds Diagnostics) Err() error {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			pe := d.ParseError
+			return &pe
+		}
+	}
+	return nil
+}
+
+// ParseWithDiagnostics parses the definition without stopping at the first error.
+// Malformed expressions and segments are skipped and reported, and the remaining
+// definition is returned as a partial Segments. Expressions which are not valid
+// against the metadata (see ValidateExpression) are reported as warnings.
+func ParseWithDiagnostics(definition string) (Segments, Diagnostics) {
+	return ParseOptions{}.ParseWithDiagnostics(definition)
+}
@@ -0,0 +1,81 @@
+package gasegment
+
+import "testing"
+
+func TestParseWithDiagnostics(t *testing.T) {
+	table := []struct {
+		definition string
+		partial    string
+		errors     []string
+		warnings   int
+	}{
+		{
+			definition: "users::condition::ga:pagePath==/a",
+			partial:    "users::condition::ga:pagePath==/a",
+		},
+		{
+			definition: "users::condition::ga:pagePath==/a,ga:pagePath,ga:pagePath==/b;==c;ga:sessions>1",
+			partial:    "users::condition::ga:pagePath==/a,ga:pagePath==/b;ga:sessions>1",
+			errors:     []string{"ga:pagePath", "=="},
+		},
+		{
+			definition: "users::condition::ga:pagePath==/a;foo::ga:sessions;sessions::sequence::ga:hits>1;->>ga:pageviews;->ga:pageviews>2",
+			partial:    "users::condition::ga:pagePath==/a;sessions::sequence::ga:hits>1;->ga:pageviews>2",
+			errors:     []string{"foo::ga:sessions", "ga:pageviews"},
+		},
+		{
+			definition: "users::foo::ga:pagePath==/a;condition::ga:pagePath==/b;sessions::condition::ga:hits;condition::ga:hits>1",
+			partial:    "users::condition::ga:pagePath==/b;sessions::condition::ga:hits>1",
+			errors:     []string{"foo::ga:pagePath==/a", "ga:hits"},
+			warnings:   1,
+		},
+		{
+			definition: "condition::ga:pagePath==/a;users::condition::ga:pagePath==/b;sessions::sequence::ga:pagePath;->>ga:pagePath==/c",
+			partial:    "users::condition::ga:pagePath==/b;sessions::sequence::ga:pagePath==/c",
+			errors:     []string{"condition::ga:pagePath==/a", "ga:pagePath"},
+		},
+		{
+			definition: "users::condition::perProduct::ga:sessions>1;ga:noSuchDimension==a",
+			partial:    "users::condition::perProduct::ga:sessions>1;ga:noSuchDimension==a",
+			warnings:   2,
+		},
+	}
+
+	for _, c := range table {
+		ss, ds := ParseWithDiagnostics(c.definition)
+		if act := ss.DefString(); act != c.partial {
+			t.Errorf("%s: unexpected partial result\n\texpected: %s\n\tactual:   %s", c.definition, c.partial, act)
+		}
+		errors := []string{}
+		warnings := 0
+		for _, d := range ds {
+			if c.definition[d.Offset:d.End] != d.Fragment {
+				t.Errorf("%s: fragment does not match span: %s", c.definition, d)
+			}
+			switch d.Severity {
+			case SeverityError:
+				errors = append(errors, d.Fragment)
+			case SeverityWarning:
+				warnings++
+			}
+		}
+		if len(errors) != len(c.errors) {
+			t.Errorf("%s: unexpected errors %v", c.definition, ds)
+			continue
+		}
+		for i := range errors {
+			if errors[i] != c.errors[i] {
+				t.Errorf("%s: unexpected error fragment %q, expected %q", c.definition, errors[i], c.errors[i])
+			}
+		}
+		if warnings != c.warnings {
+			t.Errorf("%s: unexpected warnings %v", c.definition, ds)
+		}
+		if ds.HasErrors() != (len(c.errors) > 0) {
+			t.Errorf("%s: HasErrors mismatch", c.definition)
+		}
+		if _, err := Parse(c.definition); (err != nil) != ds.HasErrors() {
+			t.Errorf("%s: Parse and ParseWithDiagnostics disagree: %v", c.definition, err)
+		}
+	}
+}
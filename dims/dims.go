@@ -0,0 +1,1016 @@
+// Code generated by gencolumns from files/columns.json. DO NOT EDIT.
+
+// Package dims has the dimensions of the Core Reporting API metadata.
+package dims
+
+import (
+	"strconv"
+
+	"github.com/wacul/gasegment"
+)
+
+// Dimension is a dimension with its metadata. Its methods build the expressions
+// allowed on a dimension, e.g. dims.PagePath.Eq("/a").
+type Dimension struct {
+	ID                gasegment.DimensionOrMetric
+	DataType          string
+	AllowedInSegments bool
+
+	target gasegment.Target
+}
+
+func dimension(id, dataType string, allowedInSegments bool) Dimension {
+	return Dimension{
+		ID:                gasegment.DimensionOrMetric(id),
+		DataType:          dataType,
+		AllowedInSegments: allowedInSegments,
+		target:            gasegment.Dim(id),
+	}
+}
+
+func (d Dimension) Eq(v string) gasegment.Cond {
+	return d.target.Eq(v)
+}
+
+func (d Dimension) Ne(v string) gasegment.Cond {
+	return d.target.Ne(v)
+}
+
+func (d Dimension) Contains(v string) gasegment.Cond {
+	return d.target.Contains(v)
+}
+
+func (d Dimension) NotContains(v string) gasegment.Cond {
+	return d.target.NotContains(v)
+}
+
+func (d Dimension) Matches(re string) gasegment.Cond {
+	return d.target.Matches(re)
+}
+
+func (d Dimension) NotMatches(re string) gasegment.Cond {
+	return d.target.NotMatches(re)
+}
+
+// In matches any of the items (`[]`).
+func (d Dimension) In(items ...string) gasegment.Cond {
+	return d.target.In(items...)
+}
+
+// NotIn matches none of the items (`![]`).
+func (d Dimension) NotIn(items ...string) gasegment.Cond {
+	return d.target.NotIn(items...)
+}
+
+func (d Dimension) Lt(v float64) gasegment.Cond {
+	return d.target.Lt(v)
+}
+
+func (d Dimension) Le(v float64) gasegment.Cond {
+	return d.target.Le(v)
+}
+
+func (d Dimension) Gt(v float64) gasegment.Cond {
+	return d.target.Gt(v)
+}
+
+func (d Dimension) Ge(v float64) gasegment.Cond {
+	return d.target.Ge(v)
+}
+
+// Between matches the values from min to max (`<>`).
+func (d Dimension) Between(min, max string) gasegment.Cond {
+	return d.target.BetweenStrings(min, max)
+}
+
+// AcquisitionCampaign is ga:acquisitionCampaign, "Acquisition Campaign".
+var AcquisitionCampaign = dimension("ga:acquisitionCampaign", "STRING", false)
+
+// AcquisitionMedium is ga:acquisitionMedium, "Acquisition Medium".
+var AcquisitionMedium = dimension("ga:acquisitionMedium", "STRING", false)
+
+// AcquisitionSource is ga:acquisitionSource, "Acquisition Source".
+var AcquisitionSource = dimension("ga:acquisitionSource", "STRING", false)
+
+// AcquisitionSourceMedium is ga:acquisitionSourceMedium, "Acquisition Source / Medium".
+var AcquisitionSourceMedium = dimension("ga:acquisitionSourceMedium", "STRING", false)
+
+// AcquisitionTrafficChannel is ga:acquisitionTrafficChannel, "Acquisition Channel".
+var AcquisitionTrafficChannel = dimension("ga:acquisitionTrafficChannel", "STRING", false)
+
+// AdContent is ga:adContent, "Ad Content".
+var AdContent = dimension("ga:adContent", "STRING", true)
+
+// AdDestinationUrl is ga:adDestinationUrl, "Destination URL".
+var AdDestinationUrl = dimension("ga:adDestinationUrl", "STRING", false)
+
+// AdDisplayUrl is ga:adDisplayUrl, "Display URL".
+var AdDisplayUrl = dimension("ga:adDisplayUrl", "STRING", false)
+
+// AdDistributionNetwork is ga:adDistributionNetwork, "Ad Distribution Network".
+var AdDistributionNetwork = dimension("ga:adDistributionNetwork", "STRING", false)
+
+// AdFormat is ga:adFormat, "Ad Format".
+var AdFormat = dimension("ga:adFormat", "STRING", false)
+
+// AdGroup is ga:adGroup, "Ad Group".
+var AdGroup = dimension("ga:adGroup", "STRING", true)
+
+// AdKeywordMatchType is ga:adKeywordMatchType, "Keyword Match Type".
+var AdKeywordMatchType = dimension("ga:adKeywordMatchType", "STRING", false)
+
+// AdMatchType is ga:adMatchType, "Query Match Type".
+var AdMatchType = dimension("ga:adMatchType", "STRING", false)
+
+// AdMatchedQuery is ga:adMatchedQuery, "Search Query".
+var AdMatchedQuery = dimension("ga:adMatchedQuery", "STRING", false)
+
+// AdPlacementDomain is ga:adPlacementDomain, "Placement Domain".
+var AdPlacementDomain = dimension("ga:adPlacementDomain", "STRING", false)
+
+// AdPlacementUrl is ga:adPlacementUrl, "Placement URL".
+var AdPlacementUrl = dimension("ga:adPlacementUrl", "STRING", false)
+
+// AdQueryWordCount is ga:adQueryWordCount, "Query Word Count".
+var AdQueryWordCount = dimension("ga:adQueryWordCount", "STRING", false)
+
+// AdSlot is ga:adSlot, "Ad Slot".
+var AdSlot = dimension("ga:adSlot", "STRING", true)
+
+// AdSlotPosition is ga:adSlotPosition, "Ad Slot Position".
+//
+// Deprecated: no longer supported.
+var AdSlotPosition = dimension("ga:adSlotPosition", "STRING", true)
+
+// AdTargetingOption is ga:adTargetingOption, "Placement Type".
+var AdTargetingOption = dimension("ga:adTargetingOption", "STRING", false)
+
+// AdTargetingType is ga:adTargetingType, "Targeting Type".
+var AdTargetingType = dimension("ga:adTargetingType", "STRING", true)
+
+// AdwordsAdGroupID is ga:adwordsAdGroupID, "AdWords Ad Group ID".
+var AdwordsAdGroupID = dimension("ga:adwordsAdGroupID", "STRING", false)
+
+// AdwordsCampaignID is ga:adwordsCampaignID, "AdWords Campaign ID".
+var AdwordsCampaignID = dimension("ga:adwordsCampaignID", "STRING", false)
+
+// AdwordsCreativeID is ga:adwordsCreativeID, "AdWords Creative ID".
+var AdwordsCreativeID = dimension("ga:adwordsCreativeID", "STRING", false)
+
+// AdwordsCriteriaID is ga:adwordsCriteriaID, "AdWords Criteria ID".
+var AdwordsCriteriaID = dimension("ga:adwordsCriteriaID", "STRING", false)
+
+// AdwordsCustomerID is ga:adwordsCustomerID, "AdWords Customer ID".
+var AdwordsCustomerID = dimension("ga:adwordsCustomerID", "STRING", false)
+
+// Affiliation is ga:affiliation, "Affiliation".
+var Affiliation = dimension("ga:affiliation", "STRING", true)
+
+// AppId is ga:appId, "App ID".
+var AppId = dimension("ga:appId", "STRING", true)
+
+// AppInstallerId is ga:appInstallerId, "App Installer ID".
+var AppInstallerId = dimension("ga:appInstallerId", "STRING", true)
+
+// AppName is ga:appName, "App Name".
+var AppName = dimension("ga:appName", "STRING", true)
+
+// AppVersion is ga:appVersion, "App Version".
+var AppVersion = dimension("ga:appVersion", "STRING", true)
+
+// Browser is ga:browser, "Browser".
+var Browser = dimension("ga:browser", "STRING", true)
+
+// BrowserSize is ga:browserSize, "Browser Size".
+var BrowserSize = dimension("ga:browserSize", "STRING", true)
+
+// BrowserVersion is ga:browserVersion, "Browser Version".
+var BrowserVersion = dimension("ga:browserVersion", "STRING", true)
+
+// Campaign is ga:campaign, "Campaign".
+var Campaign = dimension("ga:campaign", "STRING", true)
+
+// CampaignCode is ga:campaignCode, "Campaign Code".
+var CampaignCode = dimension("ga:campaignCode", "STRING", false)
+
+// ChannelGrouping is ga:channelGrouping, "Default Channel Grouping".
+var ChannelGrouping = dimension("ga:channelGrouping", "STRING", true)
+
+// CheckoutOptions is ga:checkoutOptions, "Checkout Options".
+var CheckoutOptions = dimension("ga:checkoutOptions", "STRING", true)
+
+// City is ga:city, "City".
+var City = dimension("ga:city", "STRING", true)
+
+// CityId is ga:cityId, "City ID".
+var CityId = dimension("ga:cityId", "STRING", false)
+
+// Cohort is ga:cohort, "Cohort".
+var Cohort = dimension("ga:cohort", "STRING", false)
+
+// CohortNthDay is ga:cohortNthDay, "Day".
+var CohortNthDay = dimension("ga:cohortNthDay", "STRING", false)
+
+// CohortNthMonth is ga:cohortNthMonth, "Month".
+var CohortNthMonth = dimension("ga:cohortNthMonth", "STRING", false)
+
+// CohortNthWeek is ga:cohortNthWeek, "Week".
+var CohortNthWeek = dimension("ga:cohortNthWeek", "STRING", false)
+
+// Continent is ga:continent, "Continent".
+var Continent = dimension("ga:continent", "STRING", true)
+
+// ContinentId is ga:continentId, "Continent ID".
+var ContinentId = dimension("ga:continentId", "STRING", true)
+
+// CorrelationModelId is ga:correlationModelId, "Correlation Model ID".
+var CorrelationModelId = dimension("ga:correlationModelId", "STRING", false)
+
+// Country is ga:country, "Country".
+var Country = dimension("ga:country", "STRING", true)
+
+// CountryIsoCode is ga:countryIsoCode, "Country ISO Code".
+var CountryIsoCode = dimension("ga:countryIsoCode", "STRING", false)
+
+// CurrencyCode is ga:currencyCode, "Currency Code".
+var CurrencyCode = dimension("ga:currencyCode", "STRING", false)
+
+// CustomDimension returns ga:dimensionXX, "Custom Dimension XX", for n from 1 to 20 (200 on GA360).
+func CustomDimension(n int) Dimension {
+	return dimension("ga:dimension"+strconv.Itoa(n), "STRING", true)
+}
+
+// CustomVariableKey returns ga:customVarNameXX, "Custom Variable (Key XX)", for n from 1 to 5 (50 on GA360).
+func CustomVariableKey(n int) Dimension {
+	return dimension("ga:customVarName"+strconv.Itoa(n), "STRING", true)
+}
+
+// CustomVariableValue returns ga:customVarValueXX, "Custom Variable (Value XX)", for n from 1 to 5 (50 on GA360).
+func CustomVariableValue(n int) Dimension {
+	return dimension("ga:customVarValue"+strconv.Itoa(n), "STRING", true)
+}
+
+// DataSource is ga:dataSource, "Data Source".
+var DataSource = dimension("ga:dataSource", "STRING", true)
+
+// Date is ga:date, "Date".
+var Date = dimension("ga:date", "STRING", false)
+
+// DateHour is ga:dateHour, "Hour of Day".
+var DateHour = dimension("ga:dateHour", "STRING", false)
+
+// Day is ga:day, "Day of the month".
+var Day = dimension("ga:day", "STRING", false)
+
+// DayOfWeek is ga:dayOfWeek, "Day of Week".
+var DayOfWeek = dimension("ga:dayOfWeek", "STRING", false)
+
+// DayOfWeekName is ga:dayOfWeekName, "Day of Week Name".
+var DayOfWeekName = dimension("ga:dayOfWeekName", "STRING", false)
+
+// DaysSinceLastSession is ga:daysSinceLastSession, "Days Since Last Session".
+var DaysSinceLastSession = dimension("ga:daysSinceLastSession", "STRING", true)
+
+// DaysSinceLastVisit is ga:daysSinceLastVisit, "Days Since Last Session".
+//
+// Deprecated: use DaysSinceLastSession instead.
+var DaysSinceLastVisit = dimension("ga:daysSinceLastVisit", "STRING", true)
+
+// DaysToTransaction is ga:daysToTransaction, "Days to Transaction".
+var DaysToTransaction = dimension("ga:daysToTransaction", "STRING", true)
+
+// DbmClickAdvertiser is ga:dbmClickAdvertiser, "DBM Advertiser (GA Model)".
+var DbmClickAdvertiser = dimension("ga:dbmClickAdvertiser", "STRING", false)
+
+// DbmClickAdvertiserId is ga:dbmClickAdvertiserId, "DBM Advertiser ID (GA Model)".
+var DbmClickAdvertiserId = dimension("ga:dbmClickAdvertiserId", "STRING", false)
+
+// DbmClickCreativeId is ga:dbmClickCreativeId, "DBM Creative ID (GA Model)".
+var DbmClickCreativeId = dimension("ga:dbmClickCreativeId", "STRING", false)
+
+// DbmClickExchange is ga:dbmClickExchange, "DBM Exchange (GA Model)".
+var DbmClickExchange = dimension("ga:dbmClickExchange", "STRING", false)
+
+// DbmClickExchangeId is ga:dbmClickExchangeId, "DBM Exchange ID (GA Model)".
+var DbmClickExchangeId = dimension("ga:dbmClickExchangeId", "STRING", false)
+
+// DbmClickInsertionOrder is ga:dbmClickInsertionOrder, "DBM Insertion Order (GA Model)".
+var DbmClickInsertionOrder = dimension("ga:dbmClickInsertionOrder", "STRING", false)
+
+// DbmClickInsertionOrderId is ga:dbmClickInsertionOrderId, "DBM Insertion Order ID (GA Model)".
+var DbmClickInsertionOrderId = dimension("ga:dbmClickInsertionOrderId", "STRING", false)
+
+// DbmClickLineItem is ga:dbmClickLineItem, "DBM Line Item NAME (GA Model)".
+var DbmClickLineItem = dimension("ga:dbmClickLineItem", "STRING", false)
+
+// DbmClickLineItemId is ga:dbmClickLineItemId, "DBM Line Item ID (GA Model)".
+var DbmClickLineItemId = dimension("ga:dbmClickLineItemId", "STRING", false)
+
+// DbmClickSite is ga:dbmClickSite, "DBM Site (GA Model)".
+var DbmClickSite = dimension("ga:dbmClickSite", "STRING", false)
+
+// DbmClickSiteId is ga:dbmClickSiteId, "DBM Site ID (GA Model)".
+var DbmClickSiteId = dimension("ga:dbmClickSiteId", "STRING", false)
+
+// DbmLastEventAdvertiser is ga:dbmLastEventAdvertiser, "DBM Advertiser (DFA Model)".
+var DbmLastEventAdvertiser = dimension("ga:dbmLastEventAdvertiser", "STRING", false)
+
+// DbmLastEventAdvertiserId is ga:dbmLastEventAdvertiserId, "DBM Advertiser ID (DFA Model)".
+var DbmLastEventAdvertiserId = dimension("ga:dbmLastEventAdvertiserId", "STRING", false)
+
+// DbmLastEventCreativeId is ga:dbmLastEventCreativeId, "DBM Creative ID (DFA Model)".
+var DbmLastEventCreativeId = dimension("ga:dbmLastEventCreativeId", "STRING", false)
+
+// DbmLastEventExchange is ga:dbmLastEventExchange, "DBM Exchange (DFA Model)".
+var DbmLastEventExchange = dimension("ga:dbmLastEventExchange", "STRING", false)
+
+// DbmLastEventExchangeId is ga:dbmLastEventExchangeId, "DBM Exchange ID (DFA Model)".
+var DbmLastEventExchangeId = dimension("ga:dbmLastEventExchangeId", "STRING", false)
+
+// DbmLastEventInsertionOrder is ga:dbmLastEventInsertionOrder, "DBM Insertion Order (DFA Model)".
+var DbmLastEventInsertionOrder = dimension("ga:dbmLastEventInsertionOrder", "STRING", false)
+
+// DbmLastEventInsertionOrderId is ga:dbmLastEventInsertionOrderId, "DBM Insertion Order ID (DFA Model)".
+var DbmLastEventInsertionOrderId = dimension("ga:dbmLastEventInsertionOrderId", "STRING", false)
+
+// DbmLastEventLineItem is ga:dbmLastEventLineItem, "DBM Line Item (DFA Model)".
+var DbmLastEventLineItem = dimension("ga:dbmLastEventLineItem", "STRING", false)
+
+// DbmLastEventLineItemId is ga:dbmLastEventLineItemId, "DBM Line Item ID (DFA Model)".
+var DbmLastEventLineItemId = dimension("ga:dbmLastEventLineItemId", "STRING", false)
+
+// DbmLastEventSite is ga:dbmLastEventSite, "DBM Site (DFA Model)".
+var DbmLastEventSite = dimension("ga:dbmLastEventSite", "STRING", false)
+
+// DbmLastEventSiteId is ga:dbmLastEventSiteId, "DBM Site ID (DFA Model)".
+var DbmLastEventSiteId = dimension("ga:dbmLastEventSiteId", "STRING", false)
+
+// DcmClickAd is ga:dcmClickAd, "DFA Ad (GA Model)".
+var DcmClickAd = dimension("ga:dcmClickAd", "STRING", false)
+
+// DcmClickAdId is ga:dcmClickAdId, "DFA Ad ID (GA Model)".
+var DcmClickAdId = dimension("ga:dcmClickAdId", "STRING", false)
+
+// DcmClickAdType is ga:dcmClickAdType, "DFA Ad Type (GA Model)".
+var DcmClickAdType = dimension("ga:dcmClickAdType", "STRING", false)
+
+// DcmClickAdTypeId is ga:dcmClickAdTypeId, "DFA Ad Type ID".
+var DcmClickAdTypeId = dimension("ga:dcmClickAdTypeId", "STRING", false)
+
+// DcmClickAdvertiser is ga:dcmClickAdvertiser, "DFA Advertiser (GA Model)".
+var DcmClickAdvertiser = dimension("ga:dcmClickAdvertiser", "STRING", false)
+
+// DcmClickAdvertiserId is ga:dcmClickAdvertiserId, "DFA Advertiser ID (GA Model)".
+var DcmClickAdvertiserId = dimension("ga:dcmClickAdvertiserId", "STRING", false)
+
+// DcmClickCampaign is ga:dcmClickCampaign, "DFA Campaign (GA Model)".
+var DcmClickCampaign = dimension("ga:dcmClickCampaign", "STRING", false)
+
+// DcmClickCampaignId is ga:dcmClickCampaignId, "DFA Campaign ID (GA Model)".
+var DcmClickCampaignId = dimension("ga:dcmClickCampaignId", "STRING", false)
+
+// DcmClickCreative is ga:dcmClickCreative, "DFA Creative (GA Model)".
+var DcmClickCreative = dimension("ga:dcmClickCreative", "STRING", false)
+
+// DcmClickCreativeId is ga:dcmClickCreativeId, "DFA Creative ID (GA Model)".
+var DcmClickCreativeId = dimension("ga:dcmClickCreativeId", "STRING", false)
+
+// DcmClickCreativeType is ga:dcmClickCreativeType, "DFA Creative Type (GA Model)".
+var DcmClickCreativeType = dimension("ga:dcmClickCreativeType", "STRING", false)
+
+// DcmClickCreativeTypeId is ga:dcmClickCreativeTypeId, "DFA Creative Type ID (GA Model)".
+var DcmClickCreativeTypeId = dimension("ga:dcmClickCreativeTypeId", "STRING", false)
+
+// DcmClickCreativeVersion is ga:dcmClickCreativeVersion, "DFA Creative Version (GA Model)".
+var DcmClickCreativeVersion = dimension("ga:dcmClickCreativeVersion", "STRING", false)
+
+// DcmClickRenderingId is ga:dcmClickRenderingId, "DFA Rendering ID (GA Model)".
+var DcmClickRenderingId = dimension("ga:dcmClickRenderingId", "STRING", false)
+
+// DcmClickSite is ga:dcmClickSite, "DFA Site (GA Model)".
+var DcmClickSite = dimension("ga:dcmClickSite", "STRING", false)
+
+// DcmClickSiteId is ga:dcmClickSiteId, "DFA Site ID (GA Model)".
+var DcmClickSiteId = dimension("ga:dcmClickSiteId", "STRING", false)
+
+// DcmClickSitePlacement is ga:dcmClickSitePlacement, "DFA Placement (GA Model)".
+var DcmClickSitePlacement = dimension("ga:dcmClickSitePlacement", "STRING", false)
+
+// DcmClickSitePlacementId is ga:dcmClickSitePlacementId, "DFA Placement ID (GA Model)".
+var DcmClickSitePlacementId = dimension("ga:dcmClickSitePlacementId", "STRING", false)
+
+// DcmClickSpotId is ga:dcmClickSpotId, "DFA Floodlight Configuration ID (GA Model)".
+var DcmClickSpotId = dimension("ga:dcmClickSpotId", "STRING", false)
+
+// DcmFloodlightActivity is ga:dcmFloodlightActivity, "DFA Activity".
+var DcmFloodlightActivity = dimension("ga:dcmFloodlightActivity", "STRING", false)
+
+// DcmFloodlightActivityAndGroup is ga:dcmFloodlightActivityAndGroup, "DFA Activity and Group".
+var DcmFloodlightActivityAndGroup = dimension("ga:dcmFloodlightActivityAndGroup", "STRING", false)
+
+// DcmFloodlightActivityGroup is ga:dcmFloodlightActivityGroup, "DFA Activity Group".
+var DcmFloodlightActivityGroup = dimension("ga:dcmFloodlightActivityGroup", "STRING", false)
+
+// DcmFloodlightActivityGroupId is ga:dcmFloodlightActivityGroupId, "DFA Activity Group ID".
+var DcmFloodlightActivityGroupId = dimension("ga:dcmFloodlightActivityGroupId", "STRING", false)
+
+// DcmFloodlightActivityId is ga:dcmFloodlightActivityId, "DFA Activity ID".
+var DcmFloodlightActivityId = dimension("ga:dcmFloodlightActivityId", "STRING", false)
+
+// DcmFloodlightAdvertiserId is ga:dcmFloodlightAdvertiserId, "DFA Advertiser ID".
+var DcmFloodlightAdvertiserId = dimension("ga:dcmFloodlightAdvertiserId", "STRING", false)
+
+// DcmFloodlightSpotId is ga:dcmFloodlightSpotId, "DFA Floodlight Configuration ID".
+var DcmFloodlightSpotId = dimension("ga:dcmFloodlightSpotId", "STRING", false)
+
+// DcmLastEventAd is ga:dcmLastEventAd, "DFA Ad".
+var DcmLastEventAd = dimension("ga:dcmLastEventAd", "STRING", false)
+
+// DcmLastEventAdId is ga:dcmLastEventAdId, "DFA Ad ID (DFA Model)".
+var DcmLastEventAdId = dimension("ga:dcmLastEventAdId", "STRING", false)
+
+// DcmLastEventAdType is ga:dcmLastEventAdType, "DFA Ad Type (DFA Model)".
+var DcmLastEventAdType = dimension("ga:dcmLastEventAdType", "STRING", false)
+
+// DcmLastEventAdTypeId is ga:dcmLastEventAdTypeId, "DFA Ad Type ID (DFA Model)".
+var DcmLastEventAdTypeId = dimension("ga:dcmLastEventAdTypeId", "STRING", false)
+
+// DcmLastEventAdvertiser is ga:dcmLastEventAdvertiser, "DFA Advertiser (DFA Model)".
+var DcmLastEventAdvertiser = dimension("ga:dcmLastEventAdvertiser", "STRING", false)
+
+// DcmLastEventAdvertiserId is ga:dcmLastEventAdvertiserId, "DFA Advertiser ID (DFA Model)".
+var DcmLastEventAdvertiserId = dimension("ga:dcmLastEventAdvertiserId", "STRING", false)
+
+// DcmLastEventAttributionType is ga:dcmLastEventAttributionType, "DFA Attribution Type (DFA Model)".
+var DcmLastEventAttributionType = dimension("ga:dcmLastEventAttributionType", "STRING", false)
+
+// DcmLastEventCampaign is ga:dcmLastEventCampaign, "DFA Campaign (DFA Model)".
+var DcmLastEventCampaign = dimension("ga:dcmLastEventCampaign", "STRING", false)
+
+// DcmLastEventCampaignId is ga:dcmLastEventCampaignId, "DFA Campaign ID (DFA Model)".
+var DcmLastEventCampaignId = dimension("ga:dcmLastEventCampaignId", "STRING", false)
+
+// DcmLastEventCreative is ga:dcmLastEventCreative, "DFA Creative (DFA Model)".
+var DcmLastEventCreative = dimension("ga:dcmLastEventCreative", "STRING", false)
+
+// DcmLastEventCreativeId is ga:dcmLastEventCreativeId, "DFA Creative ID (DFA Model)".
+var DcmLastEventCreativeId = dimension("ga:dcmLastEventCreativeId", "STRING", false)
+
+// DcmLastEventCreativeType is ga:dcmLastEventCreativeType, "DFA Creative Type (DFA Model)".
+var DcmLastEventCreativeType = dimension("ga:dcmLastEventCreativeType", "STRING", false)
+
+// DcmLastEventCreativeTypeId is ga:dcmLastEventCreativeTypeId, "DFA Creative Type ID (DFA Model)".
+var DcmLastEventCreativeTypeId = dimension("ga:dcmLastEventCreativeTypeId", "STRING", false)
+
+// DcmLastEventCreativeVersion is ga:dcmLastEventCreativeVersion, "DFA Creative Version (DFA Model)".
+var DcmLastEventCreativeVersion = dimension("ga:dcmLastEventCreativeVersion", "STRING", false)
+
+// DcmLastEventRenderingId is ga:dcmLastEventRenderingId, "DFA Rendering ID (DFA Model)".
+var DcmLastEventRenderingId = dimension("ga:dcmLastEventRenderingId", "STRING", false)
+
+// DcmLastEventSite is ga:dcmLastEventSite, "DFA Site (DFA Model)".
+var DcmLastEventSite = dimension("ga:dcmLastEventSite", "STRING", false)
+
+// DcmLastEventSiteId is ga:dcmLastEventSiteId, "DFA Site ID (DFA Model)".
+var DcmLastEventSiteId = dimension("ga:dcmLastEventSiteId", "STRING", false)
+
+// DcmLastEventSitePlacement is ga:dcmLastEventSitePlacement, "DFA Placement (DFA Model)".
+var DcmLastEventSitePlacement = dimension("ga:dcmLastEventSitePlacement", "STRING", false)
+
+// DcmLastEventSitePlacementId is ga:dcmLastEventSitePlacementId, "DFA Placement ID (DFA Model)".
+var DcmLastEventSitePlacementId = dimension("ga:dcmLastEventSitePlacementId", "STRING", false)
+
+// DcmLastEventSpotId is ga:dcmLastEventSpotId, "DFA Floodlight Configuration ID (DFA Model)".
+var DcmLastEventSpotId = dimension("ga:dcmLastEventSpotId", "STRING", false)
+
+// DeviceCategory is ga:deviceCategory, "Device Category".
+var DeviceCategory = dimension("ga:deviceCategory", "STRING", true)
+
+// DsAdGroup is ga:dsAdGroup, "DS Ad Group".
+var DsAdGroup = dimension("ga:dsAdGroup", "STRING", false)
+
+// DsAdGroupId is ga:dsAdGroupId, "DS Ad Group ID".
+var DsAdGroupId = dimension("ga:dsAdGroupId", "STRING", false)
+
+// DsAdvertiser is ga:dsAdvertiser, "DS Advertiser".
+var DsAdvertiser = dimension("ga:dsAdvertiser", "STRING", false)
+
+// DsAdvertiserId is ga:dsAdvertiserId, "DS Advertiser ID".
+var DsAdvertiserId = dimension("ga:dsAdvertiserId", "STRING", false)
+
+// DsAgency is ga:dsAgency, "DS Agency".
+var DsAgency = dimension("ga:dsAgency", "STRING", false)
+
+// DsAgencyId is ga:dsAgencyId, "DS Agency ID".
+var DsAgencyId = dimension("ga:dsAgencyId", "STRING", false)
+
+// DsCampaign is ga:dsCampaign, "DS Campaign".
+var DsCampaign = dimension("ga:dsCampaign", "STRING", false)
+
+// DsCampaignId is ga:dsCampaignId, "DS Campaign ID".
+var DsCampaignId = dimension("ga:dsCampaignId", "STRING", false)
+
+// DsEngineAccount is ga:dsEngineAccount, "DS Engine Account".
+var DsEngineAccount = dimension("ga:dsEngineAccount", "STRING", false)
+
+// DsEngineAccountId is ga:dsEngineAccountId, "DS Engine Account ID".
+var DsEngineAccountId = dimension("ga:dsEngineAccountId", "STRING", false)
+
+// DsKeyword is ga:dsKeyword, "DS Keyword".
+var DsKeyword = dimension("ga:dsKeyword", "STRING", false)
+
+// DsKeywordId is ga:dsKeywordId, "DS Keyword ID".
+var DsKeywordId = dimension("ga:dsKeywordId", "STRING", false)
+
+// EventAction is ga:eventAction, "Event Action".
+var EventAction = dimension("ga:eventAction", "STRING", true)
+
+// EventCategory is ga:eventCategory, "Event Category".
+var EventCategory = dimension("ga:eventCategory", "STRING", true)
+
+// EventLabel is ga:eventLabel, "Event Label".
+var EventLabel = dimension("ga:eventLabel", "STRING", true)
+
+// ExceptionDescription is ga:exceptionDescription, "Exception Description".
+var ExceptionDescription = dimension("ga:exceptionDescription", "STRING", true)
+
+// ExitPagePath is ga:exitPagePath, "Exit Page".
+var ExitPagePath = dimension("ga:exitPagePath", "STRING", true)
+
+// ExitScreenName is ga:exitScreenName, "Exit Screen".
+var ExitScreenName = dimension("ga:exitScreenName", "STRING", true)
+
+// ExperimentId is ga:experimentId, "Experiment ID".
+var ExperimentId = dimension("ga:experimentId", "STRING", true)
+
+// ExperimentVariant is ga:experimentVariant, "Variant".
+var ExperimentVariant = dimension("ga:experimentVariant", "STRING", true)
+
+// FlashVersion is ga:flashVersion, "Flash Version".
+var FlashVersion = dimension("ga:flashVersion", "STRING", true)
+
+// FullReferrer is ga:fullReferrer, "Full Referrer".
+var FullReferrer = dimension("ga:fullReferrer", "STRING", false)
+
+// GoalCompletionLocation is ga:goalCompletionLocation, "Goal Completion Location".
+var GoalCompletionLocation = dimension("ga:goalCompletionLocation", "STRING", false)
+
+// GoalPreviousStep1 is ga:goalPreviousStep1, "Goal Previous Step - 1".
+var GoalPreviousStep1 = dimension("ga:goalPreviousStep1", "STRING", false)
+
+// GoalPreviousStep2 is ga:goalPreviousStep2, "Goal Previous Step - 2".
+var GoalPreviousStep2 = dimension("ga:goalPreviousStep2", "STRING", false)
+
+// GoalPreviousStep3 is ga:goalPreviousStep3, "Goal Previous Step - 3".
+var GoalPreviousStep3 = dimension("ga:goalPreviousStep3", "STRING", false)
+
+// HasSocialSourceReferral is ga:hasSocialSourceReferral, "Social Source Referral".
+var HasSocialSourceReferral = dimension("ga:hasSocialSourceReferral", "STRING", false)
+
+// Hostname is ga:hostname, "Hostname".
+var Hostname = dimension("ga:hostname", "STRING", true)
+
+// Hour is ga:hour, "Hour".
+var Hour = dimension("ga:hour", "STRING", true)
+
+// InterestAffinityCategory is ga:interestAffinityCategory, "Affinity Category (reach)".
+var InterestAffinityCategory = dimension("ga:interestAffinityCategory", "STRING", false)
+
+// InterestInMarketCategory is ga:interestInMarketCategory, "In-Market Segment".
+var InterestInMarketCategory = dimension("ga:interestInMarketCategory", "STRING", false)
+
+// InterestOtherCategory is ga:interestOtherCategory, "Other Category".
+var InterestOtherCategory = dimension("ga:interestOtherCategory", "STRING", false)
+
+// InternalPromotionCreative is ga:internalPromotionCreative, "Internal Promotion Creative".
+var InternalPromotionCreative = dimension("ga:internalPromotionCreative", "STRING", true)
+
+// InternalPromotionId is ga:internalPromotionId, "Internal Promotion ID".
+var InternalPromotionId = dimension("ga:internalPromotionId", "STRING", true)
+
+// InternalPromotionName is ga:internalPromotionName, "Internal Promotion Name".
+var InternalPromotionName = dimension("ga:internalPromotionName", "STRING", true)
+
+// InternalPromotionPosition is ga:internalPromotionPosition, "Internal Promotion Position".
+var InternalPromotionPosition = dimension("ga:internalPromotionPosition", "STRING", true)
+
+// IsMobile is ga:isMobile, "Mobile (Including Tablet)".
+//
+// Deprecated: no longer supported.
+var IsMobile = dimension("ga:isMobile", "STRING", true)
+
+// IsTablet is ga:isTablet, "Tablet".
+//
+// Deprecated: no longer supported.
+var IsTablet = dimension("ga:isTablet", "STRING", true)
+
+// IsTrueViewVideoAd is ga:isTrueViewVideoAd, "TrueView Video Ad".
+var IsTrueViewVideoAd = dimension("ga:isTrueViewVideoAd", "STRING", false)
+
+// IsoWeek is ga:isoWeek, "ISO Week of the Year".
+var IsoWeek = dimension("ga:isoWeek", "STRING", false)
+
+// IsoYear is ga:isoYear, "ISO Year".
+var IsoYear = dimension("ga:isoYear", "STRING", false)
+
+// IsoYearIsoWeek is ga:isoYearIsoWeek, "ISO Week of ISO Year".
+var IsoYearIsoWeek = dimension("ga:isoYearIsoWeek", "STRING", false)
+
+// JavaEnabled is ga:javaEnabled, "Java Support".
+var JavaEnabled = dimension("ga:javaEnabled", "STRING", true)
+
+// Keyword is ga:keyword, "Keyword".
+var Keyword = dimension("ga:keyword", "STRING", true)
+
+// LandingPageGroup returns ga:landingContentGroupXX, "Landing Page Group XX", for n from 1 to 5.
+func LandingPageGroup(n int) Dimension {
+	return dimension("ga:landingContentGroup"+strconv.Itoa(n), "STRING", true)
+}
+
+// LandingPagePath is ga:landingPagePath, "Landing Page".
+var LandingPagePath = dimension("ga:landingPagePath", "STRING", true)
+
+// LandingScreenName is ga:landingScreenName, "Landing Screen".
+var LandingScreenName = dimension("ga:landingScreenName", "STRING", true)
+
+// Language is ga:language, "Language".
+var Language = dimension("ga:language", "STRING", true)
+
+// Latitude is ga:latitude, "Latitude".
+var Latitude = dimension("ga:latitude", "STRING", false)
+
+// Longitude is ga:longitude, "Longitude".
+var Longitude = dimension("ga:longitude", "STRING", false)
+
+// Medium is ga:medium, "Medium".
+var Medium = dimension("ga:medium", "STRING", true)
+
+// Metro is ga:metro, "Metro".
+var Metro = dimension("ga:metro", "STRING", true)
+
+// MetroId is ga:metroId, "Metro Id".
+var MetroId = dimension("ga:metroId", "STRING", false)
+
+// Minute is ga:minute, "Minute".
+var Minute = dimension("ga:minute", "STRING", true)
+
+// MobileDeviceBranding is ga:mobileDeviceBranding, "Mobile Device Branding".
+var MobileDeviceBranding = dimension("ga:mobileDeviceBranding", "STRING", true)
+
+// MobileDeviceInfo is ga:mobileDeviceInfo, "Mobile Device Info".
+var MobileDeviceInfo = dimension("ga:mobileDeviceInfo", "STRING", true)
+
+// MobileDeviceMarketingName is ga:mobileDeviceMarketingName, "Mobile Device Marketing Name".
+var MobileDeviceMarketingName = dimension("ga:mobileDeviceMarketingName", "STRING", true)
+
+// MobileDeviceModel is ga:mobileDeviceModel, "Mobile Device Model".
+var MobileDeviceModel = dimension("ga:mobileDeviceModel", "STRING", true)
+
+// MobileInputSelector is ga:mobileInputSelector, "Mobile Input Selector".
+var MobileInputSelector = dimension("ga:mobileInputSelector", "STRING", true)
+
+// Month is ga:month, "Month of the year".
+var Month = dimension("ga:month", "STRING", false)
+
+// NetworkDomain is ga:networkDomain, "Network Domain".
+var NetworkDomain = dimension("ga:networkDomain", "STRING", true)
+
+// NetworkLocation is ga:networkLocation, "Service Provider".
+var NetworkLocation = dimension("ga:networkLocation", "STRING", true)
+
+// NextPageGroup returns ga:nextContentGroupXX, "Next Page Group XX", for n from 1 to 5.
+//
+// Deprecated: no longer supported.
+func NextPageGroup(n int) Dimension {
+	return dimension("ga:nextContentGroup"+strconv.Itoa(n), "STRING", true)
+}
+
+// NextPagePath is ga:nextPagePath, "Next Page Path".
+//
+// Deprecated: no longer supported.
+var NextPagePath = dimension("ga:nextPagePath", "STRING", false)
+
+// NthDay is ga:nthDay, "Day Index".
+var NthDay = dimension("ga:nthDay", "STRING", false)
+
+// NthHour is ga:nthHour, "Hour Index".
+var NthHour = dimension("ga:nthHour", "STRING", false)
+
+// NthMinute is ga:nthMinute, "Minute Index".
+var NthMinute = dimension("ga:nthMinute", "STRING", false)
+
+// NthMonth is ga:nthMonth, "Month Index".
+var NthMonth = dimension("ga:nthMonth", "STRING", false)
+
+// NthWeek is ga:nthWeek, "Week Index".
+var NthWeek = dimension("ga:nthWeek", "STRING", false)
+
+// OperatingSystem is ga:operatingSystem, "Operating System".
+var OperatingSystem = dimension("ga:operatingSystem", "STRING", true)
+
+// OperatingSystemVersion is ga:operatingSystemVersion, "Operating System Version".
+var OperatingSystemVersion = dimension("ga:operatingSystemVersion", "STRING", true)
+
+// OrderCouponCode is ga:orderCouponCode, "Order Coupon Code".
+var OrderCouponCode = dimension("ga:orderCouponCode", "STRING", true)
+
+// PageDepth is ga:pageDepth, "Page Depth".
+var PageDepth = dimension("ga:pageDepth", "STRING", true)
+
+// PageGroup returns ga:contentGroupXX, "Page Group XX", for n from 1 to 5.
+func PageGroup(n int) Dimension {
+	return dimension("ga:contentGroup"+strconv.Itoa(n), "STRING", true)
+}
+
+// PagePath is ga:pagePath, "Page".
+var PagePath = dimension("ga:pagePath", "STRING", true)
+
+// PagePathLevel1 is ga:pagePathLevel1, "Page path level 1".
+var PagePathLevel1 = dimension("ga:pagePathLevel1", "STRING", false)
+
+// PagePathLevel2 is ga:pagePathLevel2, "Page path level 2".
+var PagePathLevel2 = dimension("ga:pagePathLevel2", "STRING", false)
+
+// PagePathLevel3 is ga:pagePathLevel3, "Page path level 3".
+var PagePathLevel3 = dimension("ga:pagePathLevel3", "STRING", false)
+
+// PagePathLevel4 is ga:pagePathLevel4, "Page path level 4".
+var PagePathLevel4 = dimension("ga:pagePathLevel4", "STRING", false)
+
+// PageTitle is ga:pageTitle, "Page Title".
+var PageTitle = dimension("ga:pageTitle", "STRING", true)
+
+// PreviousPageGroup returns ga:previousContentGroupXX, "Previous Page Group XX", for n from 1 to 5.
+func PreviousPageGroup(n int) Dimension {
+	return dimension("ga:previousContentGroup"+strconv.Itoa(n), "STRING", true)
+}
+
+// PreviousPagePath is ga:previousPagePath, "Previous Page Path".
+var PreviousPagePath = dimension("ga:previousPagePath", "STRING", false)
+
+// ProductBrand is ga:productBrand, "Product Brand".
+var ProductBrand = dimension("ga:productBrand", "STRING", true)
+
+// ProductCategory is ga:productCategory, "Product Category".
+var ProductCategory = dimension("ga:productCategory", "STRING", true)
+
+// ProductCategoryHierarchy is ga:productCategoryHierarchy, "Product Category (Enhanced Ecommerce)".
+var ProductCategoryHierarchy = dimension("ga:productCategoryHierarchy", "STRING", true)
+
+// ProductCategoryLevel returns ga:productCategoryLevelXX, "Product Category Level XX", for n from 1 to 5.
+func ProductCategoryLevel(n int) Dimension {
+	return dimension("ga:productCategoryLevel"+strconv.Itoa(n), "STRING", true)
+}
+
+// ProductCouponCode is ga:productCouponCode, "Product Coupon Code".
+var ProductCouponCode = dimension("ga:productCouponCode", "STRING", true)
+
+// ProductListName is ga:productListName, "Product List Name".
+var ProductListName = dimension("ga:productListName", "STRING", true)
+
+// ProductListPosition is ga:productListPosition, "Product List Position".
+var ProductListPosition = dimension("ga:productListPosition", "STRING", true)
+
+// ProductName is ga:productName, "Product".
+var ProductName = dimension("ga:productName", "STRING", true)
+
+// ProductSku is ga:productSku, "Product SKU".
+var ProductSku = dimension("ga:productSku", "STRING", true)
+
+// ProductVariant is ga:productVariant, "Product Variant".
+var ProductVariant = dimension("ga:productVariant", "STRING", true)
+
+// QueryProductId is ga:queryProductId, "Queried Product ID".
+var QueryProductId = dimension("ga:queryProductId", "STRING", false)
+
+// QueryProductName is ga:queryProductName, "Queried Product Name".
+var QueryProductName = dimension("ga:queryProductName", "STRING", false)
+
+// QueryProductVariation is ga:queryProductVariation, "Queried Product Variation".
+var QueryProductVariation = dimension("ga:queryProductVariation", "STRING", false)
+
+// ReferralPath is ga:referralPath, "Referral Path".
+var ReferralPath = dimension("ga:referralPath", "STRING", true)
+
+// Region is ga:region, "Region".
+var Region = dimension("ga:region", "STRING", true)
+
+// RegionId is ga:regionId, "Region ID".
+var RegionId = dimension("ga:regionId", "STRING", false)
+
+// RegionIsoCode is ga:regionIsoCode, "Region ISO Code".
+var RegionIsoCode = dimension("ga:regionIsoCode", "STRING", false)
+
+// RelatedProductId is ga:relatedProductId, "Related Product ID".
+var RelatedProductId = dimension("ga:relatedProductId", "STRING", false)
+
+// RelatedProductName is ga:relatedProductName, "Related Product Name".
+var RelatedProductName = dimension("ga:relatedProductName", "STRING", false)
+
+// RelatedProductVariation is ga:relatedProductVariation, "Related Product Variation".
+var RelatedProductVariation = dimension("ga:relatedProductVariation", "STRING", false)
+
+// ScreenColors is ga:screenColors, "Screen Colors".
+var ScreenColors = dimension("ga:screenColors", "STRING", true)
+
+// ScreenDepth is ga:screenDepth, "Screen Depth".
+var ScreenDepth = dimension("ga:screenDepth", "STRING", true)
+
+// ScreenName is ga:screenName, "Screen Name".
+var ScreenName = dimension("ga:screenName", "STRING", true)
+
+// ScreenResolution is ga:screenResolution, "Screen Resolution".
+var ScreenResolution = dimension("ga:screenResolution", "STRING", true)
+
+// SearchAfterDestinationPage is ga:searchAfterDestinationPage, "Search Destination Page".
+var SearchAfterDestinationPage = dimension("ga:searchAfterDestinationPage", "STRING", true)
+
+// SearchCategory is ga:searchCategory, "Site Search Category".
+var SearchCategory = dimension("ga:searchCategory", "STRING", true)
+
+// SearchDestinationPage is ga:searchDestinationPage, "Destination Page".
+var SearchDestinationPage = dimension("ga:searchDestinationPage", "STRING", false)
+
+// SearchKeyword is ga:searchKeyword, "Search Term".
+var SearchKeyword = dimension("ga:searchKeyword", "STRING", true)
+
+// SearchKeywordRefinement is ga:searchKeywordRefinement, "Refined Keyword".
+var SearchKeywordRefinement = dimension("ga:searchKeywordRefinement", "STRING", true)
+
+// SearchStartPage is ga:searchStartPage, "Start Page".
+var SearchStartPage = dimension("ga:searchStartPage", "STRING", false)
+
+// SearchUsed is ga:searchUsed, "Site Search Status".
+var SearchUsed = dimension("ga:searchUsed", "STRING", true)
+
+// SecondPagePath is ga:secondPagePath, "Second Page".
+var SecondPagePath = dimension("ga:secondPagePath", "STRING", false)
+
+// SessionCount is ga:sessionCount, "Count of Sessions".
+var SessionCount = dimension("ga:sessionCount", "STRING", true)
+
+// SessionDurationBucket is ga:sessionDurationBucket, "Session Duration".
+var SessionDurationBucket = dimension("ga:sessionDurationBucket", "STRING", true)
+
+// SessionsToTransaction is ga:sessionsToTransaction, "Sessions to Transaction".
+var SessionsToTransaction = dimension("ga:sessionsToTransaction", "STRING", true)
+
+// ShoppingStage is ga:shoppingStage, "Shopping Stage".
+var ShoppingStage = dimension("ga:shoppingStage", "STRING", true)
+
+// SocialActivityAction is ga:socialActivityAction, "Originating Social Action".
+//
+// Deprecated: no longer supported.
+var SocialActivityAction = dimension("ga:socialActivityAction", "STRING", false)
+
+// SocialActivityContentUrl is ga:socialActivityContentUrl, "Shared URL".
+//
+// Deprecated: no longer supported.
+var SocialActivityContentUrl = dimension("ga:socialActivityContentUrl", "STRING", false)
+
+// SocialActivityDisplayName is ga:socialActivityDisplayName, "Display Name".
+//
+// Deprecated: no longer supported.
+var SocialActivityDisplayName = dimension("ga:socialActivityDisplayName", "STRING", false)
+
+// SocialActivityEndorsingUrl is ga:socialActivityEndorsingUrl, "Endorsing URL".
+//
+// Deprecated: no longer supported.
+var SocialActivityEndorsingUrl = dimension("ga:socialActivityEndorsingUrl", "STRING", false)
+
+// SocialActivityNetworkAction is ga:socialActivityNetworkAction, "Social Network and Action".
+//
+// Deprecated: no longer supported.
+var SocialActivityNetworkAction = dimension("ga:socialActivityNetworkAction", "STRING", false)
+
+// SocialActivityPost is ga:socialActivityPost, "Social Activity Post".
+//
+// Deprecated: no longer supported.
+var SocialActivityPost = dimension("ga:socialActivityPost", "STRING", false)
+
+// SocialActivityTagsSummary is ga:socialActivityTagsSummary, "Social Tags Summary".
+//
+// Deprecated: no longer supported.
+var SocialActivityTagsSummary = dimension("ga:socialActivityTagsSummary", "STRING", false)
+
+// SocialActivityTimestamp is ga:socialActivityTimestamp, "Social Activity Timestamp".
+//
+// Deprecated: no longer supported.
+var SocialActivityTimestamp = dimension("ga:socialActivityTimestamp", "STRING", false)
+
+// SocialActivityUserHandle is ga:socialActivityUserHandle, "Social User Handle".
+//
+// Deprecated: no longer supported.
+var SocialActivityUserHandle = dimension("ga:socialActivityUserHandle", "STRING", false)
+
+// SocialActivityUserPhotoUrl is ga:socialActivityUserPhotoUrl, "User Photo URL".
+//
+// Deprecated: no longer supported.
+var SocialActivityUserPhotoUrl = dimension("ga:socialActivityUserPhotoUrl", "STRING", false)
+
+// SocialActivityUserProfileUrl is ga:socialActivityUserProfileUrl, "User Profile URL".
+//
+// Deprecated: no longer supported.
+var SocialActivityUserProfileUrl = dimension("ga:socialActivityUserProfileUrl", "STRING", false)
+
+// SocialEngagementType is ga:socialEngagementType, "Social Type".
+var SocialEngagementType = dimension("ga:socialEngagementType", "STRING", false)
+
+// SocialInteractionAction is ga:socialInteractionAction, "Social Action".
+var SocialInteractionAction = dimension("ga:socialInteractionAction", "STRING", false)
+
+// SocialInteractionNetwork is ga:socialInteractionNetwork, "Social Network".
+var SocialInteractionNetwork = dimension("ga:socialInteractionNetwork", "STRING", false)
+
+// SocialInteractionNetworkAction is ga:socialInteractionNetworkAction, "Social Network and Action".
+var SocialInteractionNetworkAction = dimension("ga:socialInteractionNetworkAction", "STRING", false)
+
+// SocialInteractionTarget is ga:socialInteractionTarget, "Social Entity".
+var SocialInteractionTarget = dimension("ga:socialInteractionTarget", "STRING", false)
+
+// SocialNetwork is ga:socialNetwork, "Social Network".
+var SocialNetwork = dimension("ga:socialNetwork", "STRING", false)
+
+// Source is ga:source, "Source".
+var Source = dimension("ga:source", "STRING", true)
+
+// SourceMedium is ga:sourceMedium, "Source / Medium".
+var SourceMedium = dimension("ga:sourceMedium", "STRING", true)
+
+// SourcePropertyDisplayName is ga:sourcePropertyDisplayName, "Source Property Display Name".
+var SourcePropertyDisplayName = dimension("ga:sourcePropertyDisplayName", "STRING", true)
+
+// SourcePropertyTrackingId is ga:sourcePropertyTrackingId, "Source Property Tracking ID".
+var SourcePropertyTrackingId = dimension("ga:sourcePropertyTrackingId", "STRING", true)
+
+// SubContinent is ga:subContinent, "Sub Continent".
+var SubContinent = dimension("ga:subContinent", "STRING", true)
+
+// SubContinentCode is ga:subContinentCode, "Sub Continent Code".
+var SubContinentCode = dimension("ga:subContinentCode", "STRING", false)
+
+// TransactionId is ga:transactionId, "Transaction ID".
+var TransactionId = dimension("ga:transactionId", "STRING", true)
+
+// UserAgeBracket is ga:userAgeBracket, "Age".
+var UserAgeBracket = dimension("ga:userAgeBracket", "STRING", false)
+
+// UserDefinedValue is ga:userDefinedValue, "User Defined Value".
+var UserDefinedValue = dimension("ga:userDefinedValue", "STRING", true)
+
+// UserGender is ga:userGender, "Gender".
+var UserGender = dimension("ga:userGender", "STRING", false)
+
+// UserTimingCategory is ga:userTimingCategory, "Timing Category".
+var UserTimingCategory = dimension("ga:userTimingCategory", "STRING", true)
+
+// UserTimingLabel is ga:userTimingLabel, "Timing Label".
+var UserTimingLabel = dimension("ga:userTimingLabel", "STRING", true)
+
+// UserTimingVariable is ga:userTimingVariable, "Timing Variable".
+var UserTimingVariable = dimension("ga:userTimingVariable", "STRING", true)
+
+// UserType is ga:userType, "User Type".
+var UserType = dimension("ga:userType", "STRING", true)
+
+// VisitCount is ga:visitCount, "Count of Sessions".
+//
+// Deprecated: use SessionCount instead.
+var VisitCount = dimension("ga:visitCount", "STRING", true)
+
+// VisitLength is ga:visitLength, "Session Duration".
+//
+// Deprecated: use SessionDurationBucket instead.
+var VisitLength = dimension("ga:visitLength", "STRING", true)
+
+// VisitorAgeBracket is ga:visitorAgeBracket, "Age".
+//
+// Deprecated: use UserAgeBracket instead.
+var VisitorAgeBracket = dimension("ga:visitorAgeBracket", "STRING", false)
+
+// VisitorGender is ga:visitorGender, "Gender".
+//
+// Deprecated: use UserGender instead.
+var VisitorGender = dimension("ga:visitorGender", "STRING", false)
+
+// VisitorType is ga:visitorType, "User Type".
+//
+// Deprecated: use UserType instead.
+var VisitorType = dimension("ga:visitorType", "STRING", true)
+
+// VisitsToTransaction is ga:visitsToTransaction, "Sessions to Transaction".
+//
+// Deprecated: use SessionsToTransaction instead.
+var VisitsToTransaction = dimension("ga:visitsToTransaction", "STRING", true)
+
+// Week is ga:week, "Week of the Year".
+var Week = dimension("ga:week", "STRING", false)
+
+// Year is ga:year, "Year".
+var Year = dimension("ga:year", "STRING", false)
+
+// YearMonth is ga:yearMonth, "Month of Year".
+var YearMonth = dimension("ga:yearMonth", "STRING", false)
+
+// YearWeek is ga:yearWeek, "Week of Year".
+var YearWeek = dimension("ga:yearWeek", "STRING", false)
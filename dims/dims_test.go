@@ -0,0 +1,65 @@
+package dims_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wacul/gasegment"
+	"github.com/wacul/gasegment/dims"
+	"github.com/wacul/gasegment/metrics"
+)
+
+func TestGenerated(t *testing.T) {
+	if dims.PagePath.ID != "ga:pagePath" || dims.PagePath.DataType != "STRING" || !dims.PagePath.AllowedInSegments {
+		t.Errorf("unexpected %#v", dims.PagePath)
+	}
+	if d := dims.CustomDimension(3); d.ID != "ga:dimension3" {
+		t.Errorf("unexpected %#v", d)
+	}
+	if m := metrics.GoalCompletions(5); m.ID != "ga:goal5Completions" || m.DataType != "INTEGER" {
+		t.Errorf("unexpected %#v", m)
+	}
+	if metrics.Users.AllowedInSegments {
+		t.Error("ga:users is not allowed in segments")
+	}
+
+	ss, err := gasegment.Users().Condition().
+		Where(dims.PagePath.Eq("/a")).Or(dims.CustomDimension(3).In("x", "y")).
+		And(metrics.Sessions.PerUser().Gt(5)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "users::condition::ga:pagePath==/a,ga:dimension3[]x|y;perUser::ga:sessions>5"; ss.DefString() != expected {
+		t.Errorf("expected %s, but %s", expected, ss.DefString())
+	}
+	if _, err := gasegment.Users().Condition().Where(dims.CustomDimension(201).Eq("a")).Build(); err == nil {
+		t.Error("ga:dimension201 must be rejected")
+	}
+}
+
+func TestDimensionMethods(t *testing.T) {
+	for _, name := range []string{"PerHit", "PerSession", "PerUser", "PerProduct", "NotBetween", "BetweenStrings"} {
+		if _, ok := reflect.TypeOf(dims.Dimension{}).MethodByName(name); ok {
+			t.Errorf("Dimension must not have %s", name)
+		}
+	}
+
+	ss, err := gasegment.Sessions().Condition().
+		Where(dims.SessionCount.Gt(2)).
+		And(dims.Date.Between("20140501", "20140531")).
+		Build()
+	if err == nil {
+		t.Fatalf("ga:date is not allowed in segments, but %s", ss.DefString())
+	}
+	ss, err = gasegment.Sessions().Condition().
+		Where(dims.SessionCount.Gt(2)).
+		And(dims.PagePath.Between("/a", "/b")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "sessions::condition::ga:sessionCount>2;ga:pagePath<>/a_/b"; ss.DefString() != expected {
+		t.Errorf("expected %s, but %s", expected, ss.DefString())
+	}
+}
@@ -0,0 +1,70 @@
+package gasegment
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// MarshalText encodes the segments as their definition, so that Segments can
+// be used in text based config formats. encoding/json uses MarshalJSON instead.
+func (scs Segments) MarshalText() ([]byte, error) {
+	return []byte(scs.DefString()), nil
+}
+
+// UnmarshalText parses and validates (see Validate) a definition. An empty
+// definition is decoded as empty segments.
+func (scs *Segments) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*scs = Segments{}
+		return nil
+	}
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	if err := parsed.Validate(); err != nil {
+		return err
+	}
+	*scs = parsed
+	return nil
+}
+
+// String returns the definition. It makes *Segments a flag.Value with Set.
+func (scs *Segments) String() string {
+	if scs == nil {
+		return ""
+	}
+	return scs.DefString()
+}
+
+// Set replaces the segments with a definition, as UnmarshalText does, e.g.
+//
+//	var segments gasegment.Segments
+//	flag.Var(&segments, "segment", "segment definition")
+func (scs *Segments) Set(definition string) error {
+	return scs.UnmarshalText([]byte(definition))
+}
+
+// Value stores the segments as their definition in a database. Nil segments
+// are stored as NULL, so that Scan reads them back as nil.
+func (scs Segments) Value() (driver.Value, error) {
+	if scs == nil {
+		return nil, nil
+	}
+	return scs.DefString(), nil
+}
+
+// Scan reads a definition stored by Value, as UnmarshalText does. NULL is read
+// as nil segments.
+func (scs *Segments) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*scs = nil
+		return nil
+	case string:
+		return scs.UnmarshalText([]byte(src))
+	case []byte:
+		return scs.UnmarshalText(src)
+	}
+	return fmt.Errorf("cannot scan %T into Segments", src)
+}
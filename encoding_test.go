@@ -0,0 +1,104 @@
+package gasegment
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Segments{}
+	_ encoding.TextUnmarshaler = &Segments{}
+	_ flag.Value               = &Segments{}
+	_ sql.Scanner              = &Segments{}
+	_ driver.Valuer            = Segments{}
+)
+
+func TestText(t *testing.T) {
+	const def = `users::condition::ga:pagePath==/a\;b;sessions::sequence::ga:pagePath==/c`
+	var ss Segments
+	if err := ss.UnmarshalText([]byte(def)); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ss.MarshalText(); string(b) != def {
+		t.Errorf("expected %s, but %s", def, b)
+	}
+	if err := ss.UnmarshalText(nil); err != nil || len(ss) != 0 {
+		t.Errorf("unexpected %v, %v", ss, err)
+	}
+
+	for _, in := range []string{"users::condition::ga:pagePath", "users::condition::ga:unknown==a"} {
+		ss := MustParse(def)
+		if err := ss.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("%s: must be rejected", in)
+		}
+		if ss.DefString() != def {
+			t.Errorf("%s: segments are modified to %s", in, ss.DefString())
+		}
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var ss Segments
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&ss, "segment", "segment definition")
+	if err := fs.Parse([]string{"-segment", "users::condition::ga:pagePath==/a"}); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("segment").Value.String() != "users::condition::ga:pagePath==/a" {
+		t.Errorf("unexpected %s", ss.DefString())
+	}
+	if err := fs.Parse([]string{"-segment", "users::condition::"}); err == nil {
+		t.Error("invalid definition must be rejected")
+	}
+}
+
+func TestSQL(t *testing.T) {
+	ss := MustParse("users::condition::ga:pagePath==/a")
+	v, err := ss.Value()
+	if err != nil || v != "users::condition::ga:pagePath==/a" {
+		t.Errorf("unexpected %v, %v", v, err)
+	}
+
+	table := []struct {
+		src      interface{}
+		expected string
+	}{
+		{"users::condition::ga:pagePath==/a", "users::condition::ga:pagePath==/a"},
+		{[]byte("sessions::condition::ga:hits>1"), "sessions::condition::ga:hits>1"},
+		{nil, ""},
+	}
+	for _, c := range table {
+		var act Segments
+		if err := act.Scan(c.src); err != nil {
+			t.Errorf("%v: %v", c.src, err)
+			continue
+		}
+		if act.DefString() != c.expected {
+			t.Errorf("expected %s, but %s", c.expected, act.DefString())
+		}
+	}
+	var act Segments
+	if err := act.Scan(1); err == nil {
+		t.Error("int must be rejected")
+	}
+
+	// nil segments are NULL, and empty ones an empty definition
+	if v, err := Segments(nil).Value(); err != nil || v != nil {
+		t.Errorf("unexpected %v, %v", v, err)
+	}
+	if v, err := (Segments{}).Value(); err != nil || v != "" {
+		t.Errorf("unexpected %v, %v", v, err)
+	}
+	act = Segments{}
+	if err := act.Scan(nil); err != nil || act != nil {
+		t.Errorf("unexpected %#v, %v", act, err)
+	}
+	if err := act.Scan(""); err != nil || act == nil || len(act) != 0 {
+		t.Errorf("unexpected %#v, %v", act, err)
+	}
+}
@@ -0,0 +1,203 @@
+package gasegment
+
+import "sort"
+
+// Clone returns a deep copy of the segments.
+func (scs Segments) Clone() Segments {
+	if scs == nil {
+		return nil
+	}
+	result := make(Segments, len(scs))
+	for i, sg := range scs {
+		sg.Condition.DateRange = sg.Condition.DateRange.clone()
+		sg.Condition.AndExpression = sg.Condition.AndExpression.clone()
+		if sg.Sequence.SequenceSteps != nil {
+			steps := make(SequenceSteps, len(sg.Sequence.SequenceSteps))
+			for j, step := range sg.Sequence.SequenceSteps {
+				step.DateRange = step.DateRange.clone()
+				step.AndExpression = step.AndExpression.clone()
+				steps[j] = step
+			}
+			sg.Sequence.SequenceSteps = steps
+		}
+		result[i] = sg
+	}
+	return result
+}
+
+func (dr *DateRange) clone() *DateRange {
+	if dr == nil {
+		return nil
+	}
+	c := *dr
+	return &c
+}
+
+func (a AndExpression) clone() AndExpression {
+	if a == nil {
+		return nil
+	}
+	result := make(AndExpression, len(a))
+	for i, or := range a {
+		if or != nil {
+			result[i] = append(make(OrExpression, 0, len(or)), or...)
+		}
+	}
+	return result
+}
+
+// Equal reports whether the segments have the same structure.
+// Unlike reflect.DeepEqual, nil and empty slices are equal and Source is ignored.
+func (scs Segments) Equal(other Segments) bool {
+	if len(scs) != len(other) {
+		return false
+	}
+	for i := range scs {
+		if !scs[i].equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (sc Segment) equal(other Segment) bool {
+	if sc.Scope != other.Scope || sc.Type != other.Type || sc.Reference != other.Reference {
+		return false
+	}
+	if sc.Condition.Exclude != other.Condition.Exclude || !sc.Condition.DateRange.equal(other.Condition.DateRange) || !sc.Condition.AndExpression.equal(other.Condition.AndExpression) {
+		return false
+	}
+	s, o := sc.Sequence, other.Sequence
+	if s.Not != o.Not || s.FirstHitMatchesFirstStep != o.FirstHitMatchesFirstStep || len(s.SequenceSteps) != len(o.SequenceSteps) {
+		return false
+	}
+	for i := range s.SequenceSteps {
+		if s.SequenceSteps[i].Type != o.SequenceSteps[i].Type || !s.SequenceSteps[i].DateRange.equal(o.SequenceSteps[i].DateRange) || !s.SequenceSteps[i].AndExpression.equal(o.SequenceSteps[i].AndExpression) {
+			return false
+		}
+	}
+	return true
+}
+
+func (dr *DateRange) equal(other *DateRange) bool {
+	if dr == nil || other == nil {
+		return dr == other
+	}
+	return dr.Start.Equal(other.Start) && dr.End.Equal(other.End)
+}
+
+func (a AndExpression) equal(other AndExpression) bool {
+	if len(a) != len(other) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(other[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if !a[i][j].equal(other[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c Expression) equal(other Expression) bool {
+	return c.MetricScope == other.MetricScope && c.Target == other.Target && c.Operator == other.Operator && c.Value == other.Value
+}
+
+// SemanticEqual reports whether the segments mean the same, that is, whether their
+// normalized forms are the same (see Normalize).
+//
+// Segments, AND groups and OR terms are compared regardless of their order and
+// duplicates, a segment without scope takes the scope of the previous segment,
+// and empty segments are ignored. The order of sequence steps is significant.
+func (scs Segments) SemanticEqual(other Segments) bool {
+	return scs.Normalize().DefString() == other.Normalize().DefString()
+}
+
+func (c Condition) canonical() Condition {
+	c.AndExpression = c.AndExpression.canonical()
+	return c
+}
+
+func (s Sequence) canonical() Sequence {
+	steps := make(SequenceSteps, len(s.SequenceSteps))
+	for i, step := range s.SequenceSteps {
+		step.DateRange = step.DateRange.clone()
+		step.AndExpression = step.AndExpression.canonical()
+		step.Source = nil
+		steps[i] = step
+	}
+	s.SequenceSteps = steps
+	return s
+}
+
+// canonical returns the AND groups and their OR terms sorted by definition without duplicates.
+func (a AndExpression) canonical() AndExpression {
+	groups := map[string]OrExpression{}
+	var keys []string
+	for _, or := range a {
+		or = or.canonical()
+		if len(or) == 0 {
+			continue
+		}
+		key := or.DefString()
+		if _, ok := groups[key]; !ok {
+			groups[key] = or
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := make(AndExpression, len(keys))
+	for i, key := range keys {
+		result[i] = groups[key]
+	}
+	return result
+}
+
+func (o OrExpression) canonical() OrExpression {
+	terms := map[string]Expression{}
+	var keys []string
+	for _, e := range o {
+		e = e.canonical()
+		key := e.DefString()
+		if _, ok := terms[key]; !ok {
+			terms[key] = e
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := make(OrExpression, len(keys))
+	for i, key := range keys {
+		result[i] = terms[key]
+	}
+	return result
+}
+
+// canonical returns the expression with its list items sorted and deduplicated
+// and its encoded value escaped canonically.
+func (c Expression) canonical() Expression {
+	c.Source = nil
+	switch {
+	case c.IsList():
+		c.Value = JoinListValue(sortUnique(SplitListValue(c.Value)))
+	case c.IsRange():
+		if vs := SplitRangeValue(c.Value); len(vs) == 2 {
+			c.Value = JoinRangeValue(vs[0], vs[1])
+		}
+	}
+	return c
+}
+
+func sortUnique(ss []string) []string {
+	sort.Strings(ss)
+	result := ss[:0]
+	for i, s := range ss {
+		if i == 0 || s != ss[i-1] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
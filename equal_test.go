@@ -0,0 +1,88 @@
+package gasegment
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	ss := MustParse("users::condition::ga:pagePath==/a,ga:hits>1;sessions::sequence::ga:pagePath==/b;->ga:pagePath==/c")
+	cloned := ss.Clone()
+	if !cloned.Equal(ss) {
+		t.Fatal("clone must be equal")
+	}
+	cloned[0].Condition.AndExpression[0][0].Value = "/x"
+	cloned[1].Sequence.SequenceSteps[1].AndExpression[0][0].Value = "/y"
+	if def := ss.DefString(); def != "users::condition::ga:pagePath==/a,ga:hits>1;sessions::sequence::ga:pagePath==/b;->ga:pagePath==/c" {
+		t.Errorf("original is modified: %s", def)
+	}
+	if Segments(nil).Clone() != nil {
+		t.Error("clone of nil must be nil")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	e := Expression{Target: "ga:pagePath", Operator: Equal, Value: "/a"}
+	manual := NewSegments(Segment{
+		Scope:     UserScope,
+		Type:      ConditionSegment,
+		Condition: Condition{AndExpression: AndExpression{OrExpression{e}}},
+		Sequence:  Sequence{SequenceSteps: SequenceSteps{}},
+	})
+	constructed := NewSegments(Segment{
+		Scope:     UserScope,
+		Type:      ConditionSegment,
+		Condition: Condition{AndExpression: NewSingleAndExpression(e)},
+	})
+	if !manual.Equal(constructed) {
+		t.Error("nil and empty slices must be equal")
+	}
+
+	parsed, _ := ParseWithOptions("users::condition::ga:pagePath==/a", ParseOptions{RecordSource: true})
+	if !parsed.Equal(constructed) {
+		t.Error("source must be ignored")
+	}
+
+	constructed[0].Condition.Exclude = true
+	if manual.Equal(constructed) {
+		t.Error("different segments must not be equal")
+	}
+}
+
+func TestSemanticEqual(t *testing.T) {
+	table := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"users::condition::ga:pagePath==/a,ga:pagePath==/b", "users::condition::ga:pagePath==/b,ga:pagePath==/a", true},
+		{"users::condition::ga:pagePath==/a;ga:hits>1", "users::condition::ga:hits>1;ga:pagePath==/a", true},
+		{"users::condition::ga:pagePath==/a,ga:pagePath==/a;ga:hits>1;ga:hits>1", "users::condition::ga:hits>1;ga:pagePath==/a", true},
+		{"users::condition::ga:pagePath==/a;sessions::condition::ga:hits>1", "sessions::condition::ga:hits>1;users::condition::ga:pagePath==/a", true},
+		{"sessions::condition::ga:pagePath==/a;condition::ga:hits>1", "sessions::condition::ga:hits>1;sessions::condition::ga:pagePath==/a", true},
+		{`users::condition::ga:pagePath==/a\,b\\`, `users::condition::ga:pagePath==/a\,b\`, true},
+		{"users::condition::ga:pagePath==/a", "sessions::condition::ga:pagePath==/a", false},
+		{"users::condition::ga:pagePath==/a,ga:hits>1", "users::condition::ga:pagePath==/a;ga:hits>1", false},
+		{"users::condition::ga:pagePath==/a", "users::condition::!ga:pagePath==/a", false},
+		{"users::sequence::ga:pagePath==/a;->>ga:pagePath==/b", "users::sequence::ga:pagePath==/b;->>ga:pagePath==/a", false},
+		{"users::sequence::ga:pagePath==/a;->>ga:hits>1,ga:hits<0", "users::sequence::ga:pagePath==/a;->>ga:hits<0,ga:hits>1", true},
+		{"users::sequence::ga:pagePath==/a;->>ga:pagePath==/b", "users::sequence::ga:pagePath==/a;->ga:pagePath==/b", false},
+	}
+	for _, c := range table {
+		a, b := MustParse(c.a), MustParse(c.b)
+		if act := a.SemanticEqual(b); act != c.equal {
+			t.Errorf("%s and %s: expected %v, but %v", c.a, c.b, c.equal, act)
+		}
+		if act := b.SemanticEqual(a); act != c.equal {
+			t.Errorf("%s and %s: not symmetric", c.b, c.a)
+		}
+	}
+
+	// a segment without scope inherits the scope of the previous one
+	inherited := NewSegments(
+		Segment{Scope: SessionScope, Type: ConditionSegment, Condition: Condition{AndExpression: NewSingleAndExpression(Expression{Target: "ga:hits", Operator: GreaterThan, Value: "1"})}},
+		Segment{Type: ConditionSegment, Condition: Condition{AndExpression: NewSingleAndExpression(Expression{Target: "ga:hits", Operator: LessThan, Value: "5"})}},
+	)
+	if !inherited.SemanticEqual(MustParse("sessions::condition::ga:hits<5;condition::ga:hits>1")) {
+		t.Error("scope must be inherited")
+	}
+	if !(Segments{{Scope: UserScope, Type: ConditionSegment}}).SemanticEqual(nil) {
+		t.Error("empty segments must be ignored")
+	}
+}
@@ -0,0 +1,150 @@
+package gasegment
+
+import "strings"
+
+// Escaping of expression values
+//
+// In a definition, `;` and `,` separate expressions, so they are written as `\;` and `\,`
+// in a value, and a backslash which would otherwise be read as part of such an escape is
+// written as `\\`. Other backslashes are taken as written, so regular expressions such as
+// `^\Q/a/\E` need no escaping.
+//
+// The values of all operators are escaped the same way. In the value of InList, NotInList
+// (`a|b|c`) and Between, NotBetween (`min_max`), a backslash also escapes the separator
+// and itself in an item (`\|`, `\_`, `\\`). Use SplitListValue/JoinListValue and
+// SplitRangeValue/JoinRangeValue, or Expression.List and Expression.Range, to convert
+// such a value from/to the items.
+//
+// For any value v and operator op, UnescapeValue(op, EscapeValue(op, v)) == v.
+
+const (
+	listSeparator  = '|'
+	rangeSeparator = '_'
+)
+
+// EscapeValue escapes the value of an expression with the operator for a definition.
+func EscapeValue(op Operator, v string) string {
+	return EscapeExpressionValue(v)
+}
+
+// UnescapeValue is the inverse of EscapeValue.
+func UnescapeValue(op Operator, s string) string {
+	return UnEscapeExpressionValue(s)
+}
+
+// EscapeExpressionValue escapes a value for a definition.
+func EscapeExpressionValue(s string) string {
+	if strings.IndexAny(s, `\;,`) < 0 {
+		return s
+	}
+	buf := make([]byte, 0, len(s)+4)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == ';' || c == ',':
+			buf = append(buf, '\\', c)
+		case c == '\\' && (i+1 == len(s) || isEscapable(s[i+1])):
+			buf = append(buf, '\\', c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return string(buf)
+}
+
+// UnEscapeExpressionValue unescapes a value of a definition.
+func UnEscapeExpressionValue(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			if isEscapable(s[i+1]) {
+				buf = append(buf, s[i+1])
+			} else {
+				// other escape sequences (e.g. `\Q`) are kept as written
+				buf = append(buf, s[i], s[i+1])
+			}
+			i++
+			continue
+		}
+		buf = append(buf, s[i])
+	}
+	return string(buf)
+}
+
+// isEscapable reports whether `\c` in a value stands for c.
+func isEscapable(c byte) bool {
+	return c == '\\' || c == ';' || c == ','
+}
+
+// valueSeparator returns the separator of list or range values of the operator, or 0.
+func valueSeparator(op Operator) byte {
+	switch op {
+	case InList, NotInList:
+		return listSeparator
+	case Between, NotBetween:
+		return rangeSeparator
+	}
+	return 0
+}
+
+// JoinListValue encodes the items of an InList or NotInList value.
+func JoinListValue(items []string) string {
+	return joinEncoded(items, listSeparator)
+}
+
+// SplitListValue decodes the items of an InList or NotInList value.
+// The empty value has no items.
+func SplitListValue(v string) []string {
+	return splitEncoded(v, listSeparator)
+}
+
+// JoinRangeValue encodes the bounds of a Between or NotBetween value.
+func JoinRangeValue(min, max string) string {
+	return joinEncoded([]string{min, max}, rangeSeparator)
+}
+
+// SplitRangeValue decodes a Between or NotBetween value. A valid range has two items.
+func SplitRangeValue(v string) []string {
+	return splitEncoded(v, rangeSeparator)
+}
+
+func joinEncoded(items []string, sep byte) string {
+	buf := make([]byte, 0, 16*len(items))
+	for i, item := range items {
+		if i > 0 {
+			buf = append(buf, sep)
+		}
+		for j := 0; j < len(item); j++ {
+			switch c := item[j]; c {
+			case '\\', sep:
+				buf = append(buf, '\\', c)
+			default:
+				buf = append(buf, c)
+			}
+		}
+	}
+	return string(buf)
+}
+
+func splitEncoded(v string, sep byte) []string {
+	if v == "" {
+		return nil
+	}
+	var items []string
+	buf := make([]byte, 0, len(v))
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; {
+		case c == '\\' && i+1 < len(v):
+			buf = append(buf, v[i+1])
+			i++
+		case c == sep:
+			items = append(items, string(buf))
+			buf = buf[:0]
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return append(items, string(buf))
+}
@@ -0,0 +1,101 @@
+package gasegment
+
+import (
+	"reflect"
+	"testing"
+)
+
+// allStrings returns every string up to n bytes made of the alphabet.
+func allStrings(alphabet string, n int) []string {
+	result := []string{""}
+	prev := []string{""}
+	for i := 0; i < n; i++ {
+		var next []string
+		for _, s := range prev {
+			for j := 0; j < len(alphabet); j++ {
+				next = append(next, s+alphabet[j:j+1])
+			}
+		}
+		result = append(result, next...)
+		prev = next
+	}
+	return result
+}
+
+func TestEscapeValue(t *testing.T) {
+	table := []struct {
+		op      Operator
+		value   string
+		escaped string
+	}{
+		{Equal, `a;b,c`, `a\;b\,c`},
+		{Equal, `a\`, `a\\`},
+		{Equal, `a\;`, `a\\\;`},
+		{Equal, `a\\b`, `a\\\b`},
+		{Regexp, `^\Q/a/\E`, `^\Q/a/\E`},
+		{Regexp, `^a|b$`, `^a|b$`},
+		{InList, `a\|b|c;d`, `a\|b|c\;d`},
+		{InList, `a;b|c`, `a\;b|c`},
+		{InList, `a\`, `a\\`},
+		{Between, `1_10`, `1_10`},
+		{Between, `a\_b_c`, `a\_b_c`},
+	}
+	for _, c := range table {
+		if act := EscapeValue(c.op, c.value); act != c.escaped {
+			t.Errorf("EscapeValue(%s, %q): expected %q, but %q", c.op, c.value, c.escaped, act)
+		}
+	}
+}
+
+func TestEscapeValueRoundTrip(t *testing.T) {
+	values := allStrings(`\;,|_a`, 5)
+	for _, op := range operators {
+		for _, expected := range values {
+			escaped := EscapeValue(op, expected)
+			if act := UnescapeValue(op, escaped); act != expected {
+				t.Errorf("%s%q: escaped to %q, unescaped to %q", op, expected, escaped, act)
+				continue
+			}
+
+			def := "users::condition::ga:pagePath" + op.String() + escaped
+			ss, err := Parse(def)
+			if err != nil {
+				t.Errorf("%s: %s", def, err)
+				continue
+			}
+			if act := ss[0].Condition.AndExpression[0][0].Value; act != expected {
+				t.Errorf("%s: expected value %q, but %q", def, expected, act)
+			}
+			if act := ss.DefString(); act != def {
+				t.Errorf("%s: not reversible %s", def, act)
+			}
+		}
+	}
+}
+
+func TestEncodedValueRoundTrip(t *testing.T) {
+	items := allStrings(`\;,|_a`, 3)
+	for _, x := range items {
+		for _, y := range items {
+			list := JoinListValue([]string{x, y})
+			if act := SplitListValue(list); !reflect.DeepEqual(act, []string{x, y}) {
+				t.Errorf("list %q %q: encoded %q, decoded %q", x, y, list, act)
+			}
+			def := Expression{Target: "ga:pagePath", Operator: InList, Value: list}.DefString()
+			if act, err := MustParse("users::condition::" + def)[0].Condition.AndExpression[0][0].List(); err != nil || !reflect.DeepEqual(act, []string{x, y}) {
+				t.Errorf("list %q %q: %s parsed to %q, %v", x, y, def, act, err)
+			}
+			rng := JoinRangeValue(x, y)
+			if act := SplitRangeValue(rng); !reflect.DeepEqual(act, []string{x, y}) {
+				t.Errorf("range %q %q: encoded %q, decoded %q", x, y, rng, act)
+			}
+		}
+	}
+
+	if act := SplitListValue(""); act != nil {
+		t.Errorf("empty list must have no items, but %q", act)
+	}
+	if act := SplitListValue(`a\b|c`); !reflect.DeepEqual(act, []string{"ab", "c"}) {
+		t.Errorf("unexpected items %q", act)
+	}
+}
@@ -0,0 +1,362 @@
+// Command gencolumns generates the dims and metrics packages from files/columns.json.
+//
+//	go run ./internal/gencolumns -o . files/columns.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/wacul/gasegment"
+)
+
+// names overrides the Go names which can't be derived from the id or the UI name.
+var names = map[string]string{
+	"ga:1dayUsers":  "OneDayUsers",
+	"ga:7dayUsers":  "SevenDayUsers",
+	"ga:14dayUsers": "FourteenDayUsers",
+	"ga:30dayUsers": "ThirtyDayUsers",
+	"ga:metricXX":   "CustomMetric",
+}
+
+type column struct {
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type entry struct {
+	Name              string
+	ID                string
+	UIName            string
+	DataType          string
+	AllowedInSegments bool
+	Deprecated        bool
+	ReplacedBy        string // Go name of the replacement
+	Type, Ctor        string // ProductMetric and productMetric for product scoped metrics
+
+	// set for templated ids such as ga:dimensionXX
+	Prefix, Suffix string
+	Min, Max       string
+	PremiumMax     string
+}
+
+type packageData struct {
+	Package string
+	Doc     string
+	Type    string
+	Ctor    string
+	Entries []entry
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by gencolumns from files/columns.json. DO NOT EDIT.
+
+// Package {{.Package}} has the {{.Doc}} of the Core Reporting API metadata.
+package {{.Package}}
+
+import (
+	"strconv"
+
+	"github.com/wacul/gasegment"
+)
+{{if eq .Package "dims"}}
+// Dimension is a dimension with its metadata. Its methods build the expressions
+// allowed on a dimension, e.g. dims.PagePath.Eq("/a").
+type Dimension struct {
+	ID                gasegment.DimensionOrMetric
+	DataType          string
+	AllowedInSegments bool
+
+	target gasegment.Target
+}
+
+func dimension(id, dataType string, allowedInSegments bool) Dimension {
+	return Dimension{
+		ID:                gasegment.DimensionOrMetric(id),
+		DataType:          dataType,
+		AllowedInSegments: allowedInSegments,
+		target:            gasegment.Dim(id),
+	}
+}
+
+func (d Dimension) Eq(v string) gasegment.Cond {
+	return d.target.Eq(v)
+}
+
+func (d Dimension) Ne(v string) gasegment.Cond {
+	return d.target.Ne(v)
+}
+
+func (d Dimension) Contains(v string) gasegment.Cond {
+	return d.target.Contains(v)
+}
+
+func (d Dimension) NotContains(v string) gasegment.Cond {
+	return d.target.NotContains(v)
+}
+
+func (d Dimension) Matches(re string) gasegment.Cond {
+	return d.target.Matches(re)
+}
+
+func (d Dimension) NotMatches(re string) gasegment.Cond {
+	return d.target.NotMatches(re)
+}
+
+// In matches any of the items (` + "`[]`" + `).
+func (d Dimension) In(items ...string) gasegment.Cond {
+	return d.target.In(items...)
+}
+
+// NotIn matches none of the items (` + "`![]`" + `).
+func (d Dimension) NotIn(items ...string) gasegment.Cond {
+	return d.target.NotIn(items...)
+}
+
+func (d Dimension) Lt(v float64) gasegment.Cond {
+	return d.target.Lt(v)
+}
+
+func (d Dimension) Le(v float64) gasegment.Cond {
+	return d.target.Le(v)
+}
+
+func (d Dimension) Gt(v float64) gasegment.Cond {
+	return d.target.Gt(v)
+}
+
+func (d Dimension) Ge(v float64) gasegment.Cond {
+	return d.target.Ge(v)
+}
+
+// Between matches the values from min to max (` + "`<>`" + `).
+func (d Dimension) Between(min, max string) gasegment.Cond {
+	return d.target.BetweenStrings(min, max)
+}
+{{else}}
+// Metric is a metric with its metadata. Its methods build the expressions
+// allowed on a metric, e.g. metrics.Sessions.PerUser().Gt(5).
+type Metric struct {
+	ID                gasegment.DimensionOrMetric
+	DataType          string
+	AllowedInSegments bool
+
+	target gasegment.Target
+}
+
+// ProductMetric is a metric which also accepts the perProduct:: scope.
+type ProductMetric struct {
+	Metric
+}
+
+func metric(id, dataType string, allowedInSegments bool) Metric {
+	return Metric{
+		ID:                gasegment.DimensionOrMetric(id),
+		DataType:          dataType,
+		AllowedInSegments: allowedInSegments,
+		target:            gasegment.Metric(id),
+	}
+}
+
+func productMetric(id, dataType string, allowedInSegments bool) ProductMetric {
+	return ProductMetric{metric(id, dataType, allowedInSegments)}
+}
+
+func (m Metric) PerHit() Metric {
+	m.target = m.target.PerHit()
+	return m
+}
+
+func (m Metric) PerSession() Metric {
+	m.target = m.target.PerSession()
+	return m
+}
+
+func (m Metric) PerUser() Metric {
+	m.target = m.target.PerUser()
+	return m
+}
+
+func (m ProductMetric) PerProduct() Metric {
+	m.target = m.target.PerProduct()
+	return m.Metric
+}
+
+func (m Metric) Eq(v float64) gasegment.Cond {
+	return m.target.Eq(gasegment.FormatNumber(v))
+}
+
+func (m Metric) Ne(v float64) gasegment.Cond {
+	return m.target.Ne(gasegment.FormatNumber(v))
+}
+
+func (m Metric) Lt(v float64) gasegment.Cond {
+	return m.target.Lt(v)
+}
+
+func (m Metric) Le(v float64) gasegment.Cond {
+	return m.target.Le(v)
+}
+
+func (m Metric) Gt(v float64) gasegment.Cond {
+	return m.target.Gt(v)
+}
+
+func (m Metric) Ge(v float64) gasegment.Cond {
+	return m.target.Ge(v)
+}
+
+// Between matches the numbers greater than min and less than max (` + "`<>`" + `).
+func (m Metric) Between(min, max float64) gasegment.Cond {
+	return m.target.Between(min, max)
+}
+
+// NotBetween matches the numbers not matched by Between (` + "`!<>`" + `).
+func (m Metric) NotBetween(min, max float64) gasegment.Cond {
+	return m.target.NotBetween(min, max)
+}
+{{end}}{{range .Entries}}{{if .Prefix}}
+// {{.Name}} returns {{.ID}}, "{{.UIName}}", for n from {{.Min}} to {{.Max}}{{if .PremiumMax}} ({{.PremiumMax}} on GA360){{end}}.{{if .Deprecated}}
+//
+// Deprecated: {{if .ReplacedBy}}use {{.ReplacedBy}} instead{{else}}no longer supported{{end}}.{{end}}
+func {{.Name}}(n int) {{.Type}} {
+	return {{.Ctor}}({{printf "%q" .Prefix}}+strconv.Itoa(n){{if .Suffix}}+{{printf "%q" .Suffix}}{{end}}, {{printf "%q" .DataType}}, {{.AllowedInSegments}})
+}
+{{else}}
+// {{.Name}} is {{.ID}}, "{{.UIName}}".{{if .Deprecated}}
+//
+// Deprecated: {{if .ReplacedBy}}use {{.ReplacedBy}} instead{{else}}no longer supported{{end}}.{{end}}
+var {{.Name}} = {{.Ctor}}({{printf "%q" .ID}}, {{printf "%q" .DataType}}, {{.AllowedInSegments}})
+{{end}}{{end}}`))
+
+func main() {
+	out := flag.String("o", ".", "directory to write the dims and metrics packages into")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: gencolumns [-o dir] columns.json")
+	}
+	b, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := generate(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(*out, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the sources of dims/dims.go and metrics/metrics.go.
+func generate(columnsJSON []byte) (map[string][]byte, error) {
+	var columns struct {
+		Items []column `json:"items"`
+	}
+	if err := json.Unmarshal(columnsJSON, &columns); err != nil {
+		return nil, err
+	}
+
+	pkgs := map[string]*packageData{
+		"DIMENSION": {Package: "dims", Doc: "dimensions", Type: "Dimension", Ctor: "dimension"},
+		"METRIC":    {Package: "metrics", Doc: "metrics", Type: "Metric", Ctor: "metric"},
+	}
+	for _, c := range columns.Items {
+		pkg, ok := pkgs[c.Attributes["type"]]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown type %q", c.ID, c.Attributes["type"])
+		}
+		e := entry{
+			ID:                c.ID,
+			UIName:            c.Attributes["uiName"],
+			DataType:          c.Attributes["dataType"],
+			AllowedInSegments: c.Attributes["allowedInSegments"] == "true",
+			Deprecated:        c.Attributes["status"] == "DEPRECATED",
+			ReplacedBy:        c.Attributes["replacedBy"],
+			Type:              pkg.Type,
+			Ctor:              pkg.Ctor,
+		}
+		if ca, err := gasegment.GetDimensionOrMetricAttributes(c.ID); err == nil && ca.ProductScoped {
+			e.Type, e.Ctor = "ProductMetric", "productMetric"
+		}
+		if i := strings.Index(c.ID, "XX"); i >= 0 {
+			e.Prefix, e.Suffix = c.ID[:i], c.ID[i+2:]
+			e.Min, e.Max = c.Attributes["minTemplateIndex"], c.Attributes["maxTemplateIndex"]
+			e.PremiumMax = c.Attributes["premiumMaxTemplateIndex"]
+			e.Name = goName(e.UIName)
+		} else {
+			e.Name = goName(strings.TrimPrefix(c.ID, "ga:"))
+		}
+		if name, ok := names[c.ID]; ok {
+			e.Name = name
+		}
+		if !token.IsIdentifier(e.Name) || e.Name == pkg.Type || e.Name == e.Type {
+			return nil, fmt.Errorf("%s: invalid Go name %q", c.ID, e.Name)
+		}
+		pkg.Entries = append(pkg.Entries, e)
+	}
+
+	goNames := map[string]string{}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Entries {
+			goNames[e.ID] = e.Name
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, pkg := range pkgs {
+		for i, e := range pkg.Entries {
+			if name, ok := goNames[e.ReplacedBy]; ok {
+				pkg.Entries[i].ReplacedBy = name
+			}
+		}
+		sort.Slice(pkg.Entries, func(i, j int) bool { return pkg.Entries[i].Name < pkg.Entries[j].Name })
+		for i := 1; i < len(pkg.Entries); i++ {
+			if a, b := pkg.Entries[i-1], pkg.Entries[i]; a.Name == b.Name {
+				return nil, fmt.Errorf("%s and %s have the same Go name %s", a.ID, b.ID, a.Name)
+			}
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, pkg); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Join(pkg.Package, pkg.Package+".go")] = src
+	}
+	return files, nil
+}
+
+// goName makes an exported Go name from an id or a UI name, e.g. `PagePath`
+// from `pagePath` and `CustomDimension` from `Custom Dimension XX`.
+func goName(s string) string {
+	var buf []rune
+	upper := true
+	for _, r := range strings.Replace(s, "XX", "", -1) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			buf = append(buf, r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return string(buf)
+}
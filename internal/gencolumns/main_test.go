@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	b, err := ioutil.ReadFile("../../files/columns.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, src := range files {
+		act, err := ioutil.ReadFile(filepath.Join("../..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(act, src) {
+			t.Errorf("%s is out of date; run go generate", name)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	table := map[string]string{
+		"pagePath":                 "PagePath",
+		"Custom Dimension XX":      "CustomDimension",
+		"Goal XX Completions":      "GoalCompletions",
+		"Custom Variable (Key XX)": "CustomVariableKey",
+	}
+	for s, expected := range table {
+		if act := goName(s); act != expected {
+			t.Errorf("%s: expected %s, but %s", s, expected, act)
+		}
+	}
+}
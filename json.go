@@ -0,0 +1,340 @@
+package gasegment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONSchemaVersion is the version of the JSON encoding of Segments, described
+// by schema/segments.schema.json. It is written to the "version" property and
+// UnmarshalJSON rejects other versions.
+const JSONSchemaVersion = 1
+
+// JSON names of the scopes, types, step types and operators, by their definition strings.
+var (
+	jsonScopes = map[string]string{
+		string(UserScope):    "users",
+		string(SessionScope): "sessions",
+	}
+	jsonTypes = map[string]string{
+		string(ConditionSegment): "condition",
+		string(SequenceSegment):  "sequence",
+		string(ReferenceSegment): "reference",
+	}
+	jsonMetricScopes = map[string]string{
+		string(PerHit):     "perHit",
+		string(PerSession): "perSession",
+		string(PerUser):    "perUser",
+		string(PerProduct): "perProduct",
+	}
+	jsonStepTypes = map[string]string{
+		string(FirstStep):           "first",
+		string(Precedes):            "precedes",
+		string(ImmediatelyPrecedes): "immediatelyPrecedes",
+	}
+	jsonOperators = map[string]string{
+		string(Equal):                "==",
+		string(NotEqual):             "!=",
+		string(LessThan):             "<",
+		string(LessThanEqual):        "<=",
+		string(GreaterThan):          ">",
+		string(GreaterThanEqual):     ">=",
+		string(Between):              "<>",
+		string(NotBetween):           "!<>",
+		string(InList):               "[]",
+		string(NotInList):            "![]",
+		string(ContainsSubstring):    "=@",
+		string(NotContainsSubstring): "!@",
+		string(Regexp):               "=~",
+		string(NotRegexp):            "!~",
+	}
+)
+
+func jsonName(kind string, names map[string]string, v string) (string, error) {
+	name, ok := names[v]
+	if !ok {
+		return "", fmt.Errorf("json: unknown %s %q", kind, v)
+	}
+	return name, nil
+}
+
+func fromJSONName(kind string, names map[string]string, name string) (string, error) {
+	for v, n := range names {
+		if n == name {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("json: unknown %s %q", kind, name)
+}
+
+// marshalJSON is json.Marshal without HTML escaping, so that operators such as
+// `<>` stay readable. json.Marshal of Segments still escapes them; encode with a
+// json.Encoder and SetEscapeHTML(false) to keep them.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+type jsonSegments struct {
+	Version  int       `json:"version"`
+	Segments []Segment `json:"segments"`
+}
+
+// MarshalJSON encodes the segments as {"version": 1, "segments": [...]}.
+func (scs Segments) MarshalJSON() ([]byte, error) {
+	segments := []Segment(scs)
+	if segments == nil {
+		segments = []Segment{}
+	}
+	return marshalJSON(jsonSegments{JSONSchemaVersion, segments})
+}
+
+func (scs *Segments) UnmarshalJSON(b []byte) error {
+	var js jsonSegments
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	if js.Version != JSONSchemaVersion {
+		return fmt.Errorf("json: unsupported version %d", js.Version)
+	}
+	ss := Segments(js.Segments)
+	// like Parse, the targets are not validated; see Segments.Validate
+	if err := checkStructure(ss); err != nil {
+		return fmt.Errorf("json: %s", err)
+	}
+	*scs = ss
+	return nil
+}
+
+type jsonSegment struct {
+	Scope     string     `json:"scope,omitempty"`
+	Type      string     `json:"type"`
+	Condition *Condition `json:"condition,omitempty"`
+	Sequence  *Sequence  `json:"sequence,omitempty"`
+	Reference string     `json:"reference,omitempty"`
+}
+
+func (sc Segment) MarshalJSON() ([]byte, error) {
+	var js jsonSegment
+	var err error
+	if sc.Scope != "" {
+		if js.Scope, err = jsonName("scope", jsonScopes, string(sc.Scope)); err != nil {
+			return nil, err
+		}
+	}
+	if js.Type, err = jsonName("segment type", jsonTypes, string(sc.Type)); err != nil {
+		return nil, err
+	}
+	switch sc.Type {
+	case ConditionSegment:
+		js.Condition = &sc.Condition
+	case SequenceSegment:
+		js.Sequence = &sc.Sequence
+	case ReferenceSegment:
+		js.Reference = sc.Reference.ID
+	}
+	return marshalJSON(js)
+}
+
+func (sc *Segment) UnmarshalJSON(b []byte) error {
+	var js jsonSegment
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	var s Segment
+	if js.Scope != "" {
+		scope, err := fromJSONName("scope", jsonScopes, js.Scope)
+		if err != nil {
+			return err
+		}
+		s.Scope = SegmentScope(scope)
+	}
+	t, err := fromJSONName("segment type", jsonTypes, js.Type)
+	if err != nil {
+		return err
+	}
+	s.Type = SegmentType(t)
+	switch {
+	case s.Type == ConditionSegment && js.Condition != nil:
+		s.Condition = *js.Condition
+	case s.Type == SequenceSegment && js.Sequence != nil:
+		s.Sequence = *js.Sequence
+	case s.Type == ReferenceSegment && js.Reference != "":
+		if s.Scope != "" {
+			return fmt.Errorf("json: reference %s cannot have a scope", js.Reference)
+		}
+		s.Reference = SegmentRef{ID: js.Reference}
+	default:
+		return fmt.Errorf("json: %s segment has no %s", js.Type, js.Type)
+	}
+	if err := checkStructure(s); err != nil {
+		return fmt.Errorf("json: %s", err)
+	}
+	*sc = s
+	return nil
+}
+
+type jsonCondition struct {
+	Exclude bool          `json:"exclude"`
+	And     AndExpression `json:"and"`
+}
+
+func (c Condition) MarshalJSON() ([]byte, error) {
+	ae := c.Groups()
+	if ae == nil {
+		ae = AndExpression{}
+	}
+	return marshalJSON(jsonCondition{c.Exclude, ae})
+}
+
+func (c *Condition) UnmarshalJSON(b []byte) error {
+	var jc jsonCondition
+	if err := json.Unmarshal(b, &jc); err != nil {
+		return err
+	}
+	cond := Condition{Exclude: jc.Exclude}
+	cond.DateRange, cond.AndExpression = leadingDateRange(jc.And)
+	if err := checkStructure(cond); err != nil {
+		return fmt.Errorf("json: %s", err)
+	}
+	*c = cond
+	return nil
+}
+
+type jsonSequence struct {
+	Not                      bool          `json:"not"`
+	FirstHitMatchesFirstStep bool          `json:"firstHitMatchesFirstStep"`
+	Steps                    SequenceSteps `json:"steps"`
+}
+
+func (s Sequence) MarshalJSON() ([]byte, error) {
+	steps := s.SequenceSteps
+	if steps == nil {
+		steps = SequenceSteps{}
+	}
+	return marshalJSON(jsonSequence{s.Not, s.FirstHitMatchesFirstStep, steps})
+}
+
+func (s *Sequence) UnmarshalJSON(b []byte) error {
+	var js jsonSequence
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	seq := Sequence{Not: js.Not, FirstHitMatchesFirstStep: js.FirstHitMatchesFirstStep, SequenceSteps: js.Steps}
+	if err := checkStructure(seq); err != nil {
+		return fmt.Errorf("json: %s", err)
+	}
+	*s = seq
+	return nil
+}
+
+type jsonSequenceStep struct {
+	Type string        `json:"type"`
+	And  AndExpression `json:"and"`
+}
+
+func (ss SequenceStep) MarshalJSON() ([]byte, error) {
+	t, err := jsonName("step type", jsonStepTypes, string(ss.Type))
+	if err != nil {
+		return nil, err
+	}
+	ae := ss.Groups()
+	if ae == nil {
+		ae = AndExpression{}
+	}
+	return marshalJSON(jsonSequenceStep{t, ae})
+}
+
+func (ss *SequenceStep) UnmarshalJSON(b []byte) error {
+	var js jsonSequenceStep
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	t, err := fromJSONName("step type", jsonStepTypes, js.Type)
+	if err != nil {
+		return err
+	}
+	step := SequenceStep{Type: SequenceStepType(t), AndExpression: js.And}
+	if step.Type == FirstStep {
+		step.DateRange, step.AndExpression = leadingDateRange(js.And)
+	}
+	*ss = step
+	return nil
+}
+
+// jsonExpression has the value of an in-list as "values" and the value of a
+// range as "min" and "max", both unescaped. Other values are in "value".
+type jsonExpression struct {
+	MetricScope string   `json:"metricScope,omitempty"`
+	Target      string   `json:"target"`
+	Operator    string   `json:"operator"`
+	Value       *string  `json:"value,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Min         *string  `json:"min,omitempty"`
+	Max         *string  `json:"max,omitempty"`
+}
+
+func (c Expression) MarshalJSON() ([]byte, error) {
+	je := jsonExpression{Target: c.Target.String()}
+	var err error
+	if c.MetricScope != Default {
+		if je.MetricScope, err = jsonName("metric scope", jsonMetricScopes, string(c.MetricScope)); err != nil {
+			return nil, err
+		}
+	}
+	if je.Operator, err = jsonName("operator", jsonOperators, string(c.Operator)); err != nil {
+		return nil, err
+	}
+	switch {
+	case c.IsList():
+		je.Values = SplitListValue(c.Value)
+	case c.IsRange() && len(SplitRangeValue(c.Value)) == 2:
+		vs := SplitRangeValue(c.Value)
+		je.Min, je.Max = &vs[0], &vs[1]
+	default:
+		je.Value = &c.Value
+	}
+	return marshalJSON(je)
+}
+
+func (c *Expression) UnmarshalJSON(b []byte) error {
+	var je jsonExpression
+	if err := json.Unmarshal(b, &je); err != nil {
+		return err
+	}
+	if je.Target == "" {
+		return fmt.Errorf("json: expression has no target")
+	}
+	op, err := fromJSONName("operator", jsonOperators, je.Operator)
+	if err != nil {
+		return err
+	}
+	e := Expression{Target: DimensionOrMetric(je.Target), Operator: Operator(op)}
+	if je.MetricScope != "" {
+		ms, err := fromJSONName("metric scope", jsonMetricScopes, je.MetricScope)
+		if err != nil {
+			return err
+		}
+		e.MetricScope = MetricScope(ms)
+	}
+	switch {
+	case je.Value != nil:
+		e.Value = *je.Value
+	case e.IsList():
+		e.Value = JoinListValue(je.Values)
+	case e.IsRange() && je.Min != nil && je.Max != nil:
+		e.Value = JoinRangeValue(*je.Min, *je.Max)
+	case e.IsRange():
+		return fmt.Errorf("json: %s%s has no min and max", je.Target, je.Operator)
+	default:
+		return fmt.Errorf("json: %s%s has no value", je.Target, je.Operator)
+	}
+	*c = e
+	return nil
+}
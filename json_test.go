@@ -0,0 +1,163 @@
+package gasegment
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	ss := MustParse(`users::condition::!perUser::ga:sessions>5,ga:pagePath[]/a\|b|/c;ga:hits<>1_10;sessions::sequence::^ga:pagePath==/a\;b;->>ga:pagePath=~^/x`)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(ss); err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.TrimSpace(buf.Bytes())
+	expected := `{"version":1,"segments":[` +
+		`{"scope":"users","type":"condition","condition":{"exclude":true,"and":[[{"metricScope":"perUser","target":"ga:sessions","operator":">","value":"5"},{"target":"ga:pagePath","operator":"[]","values":["/a|b","/c"]}],[{"target":"ga:hits","operator":"<>","min":"1","max":"10"}]]}},` +
+		`{"scope":"sessions","type":"sequence","sequence":{"not":false,"firstHitMatchesFirstStep":true,"steps":[{"type":"first","and":[[{"target":"ga:pagePath","operator":"==","value":"/a;b"}]]},{"type":"precedes","and":[[{"target":"ga:pagePath","operator":"=~","value":"^/x"}]]}]}}]}`
+	if string(b) != expected {
+		t.Errorf("expected: %s\n\tactual:   %s", expected, b)
+	}
+
+	for _, ts := range set {
+		b, err := json.Marshal(ts.object)
+		if err != nil {
+			t.Errorf("%s: %v", ts.definition, err)
+			continue
+		}
+		var act Segments
+		if err := json.Unmarshal(b, &act); err != nil {
+			t.Errorf("%s: %v", ts.definition, err)
+			continue
+		}
+		if !act.Equal(ts.object) {
+			t.Errorf("%s: round trip is %s", ts.definition, act.DefString())
+		}
+	}
+
+	var ref Segments
+	if err := json.Unmarshal([]byte(`{"version":1,"segments":[{"type":"reference","reference":"-1"}]}`), &ref); err != nil || ref.DefString() != "gaid::-1" {
+		t.Errorf("unexpected %s, %v", ref.DefString(), err)
+	}
+	if b, _ := json.Marshal(Segments(nil)); string(b) != `{"version":1,"segments":[]}` {
+		t.Errorf("unexpected %s", b)
+	}
+}
+
+// TestJSONParseRoundTrip decodes what Parse accepts, whether Validate accepts it or not.
+func TestJSONParseRoundTrip(t *testing.T) {
+	table := []string{
+		"users::condition::ga:fooBar==a",
+		"users::condition::perProduct::ga:sessions>1",
+		"users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:pagePath==/a;condition::!ga:hits>1;sessions::sequence::ga:pagePath==/a;->>ga:pagePath==/b",
+		"gaid::-1",
+	}
+	for _, def := range table {
+		ss := MustParse(def)
+		b, err := json.Marshal(ss)
+		if err != nil {
+			t.Errorf("%s: %v", def, err)
+			continue
+		}
+		var act Segments
+		if err := json.Unmarshal(b, &act); err != nil {
+			t.Errorf("%s: %v", def, err)
+			continue
+		}
+		if !act.Equal(ss) {
+			t.Errorf("%s: round trip is %s", def, act.DefString())
+		}
+	}
+
+	// the scope is inherited as in a definition
+	var ss Segments
+	in := `{"version":1,"segments":[{"scope":"users","type":"condition","condition":{"and":[[{"target":"ga:pagePath","operator":"==","value":"/a"}]]}},{"type":"condition","condition":{"and":[[{"target":"ga:hits","operator":">","value":"1"}]]}}]}`
+	if err := json.Unmarshal([]byte(in), &ss); err != nil {
+		t.Errorf("%s: %v", in, err)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	table := map[string]string{
+		`{"segments":[]}`:             "json: unsupported version 0",
+		`{"version":2,"segments":[]}`: "json: unsupported version 2",
+		`{"version":1,"segments":[{"scope":"hits","type":"condition"}]}`:                                                                                            `json: unknown scope "hits"`,
+		`{"version":1,"segments":[{"type":"condition"}]}`:                                                                                                           "json: condition segment has no condition",
+		`{"version":1,"segments":[{"scope":"users","type":"reference","reference":"-1"}]}`:                                                                          "json: reference -1 cannot have a scope",
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"target":"ga:a","operator":"~"}]]}}]}`:                                                  `json: unknown operator "~"`,
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"target":"ga:a","operator":"=="}]]}}]}`:                                                 "json: ga:a== has no value",
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"target":"ga:a","operator":"<>","min":"1"}]]}}]}`:                                       "json: ga:a<> has no min and max",
+		`{"version":1,"segments":[{"type":"sequence","sequence":{"steps":[{"type":"then","and":[]}]}}]}`:                                                            `json: unknown step type "then"`,
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"metricScope":"perHits","target":"ga:a","operator":"==","value":""}]]}}]}`:              `json: unknown metric scope "perHits"`,
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[]}}]}`:                                                                                    "json: condition: no expression",
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[]]}}]}`:                                                                                  "json: condition.and[0]: no expression",
+		`{"version":1,"segments":[{"type":"sequence","sequence":{"steps":[]}}]}`:                                                                                    "json: sequence: no step",
+		`{"version":1,"segments":[{"type":"sequence","sequence":{"steps":[{"type":"precedes","and":[[{"target":"ga:pagePath","operator":"==","value":"/a"}]]}]}}]}`: "json: sequence.steps[0]: first step cannot follow another with ;->>",
+		`{"version":1,"segments":[{"type":"sequence","sequence":{"steps":[{"type":"first","and":[[{"target":"ga:pagePath","operator":"==","value":"/a"}]]},{"type":"precedes","and":[[{"target":"dateOfSession","operator":"<>","min":"2014-05-20","max":"2014-05-30"}]]}]}}]}`: "json: sequence.steps[1].and[0].or[0]: dateOfSession is only allowed in the first step of a sequence",
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"target":"dateOfSession","operator":"<>","min":"2014-05-20","max":"2014-05-30"}],[{"target":"dateOfSession","operator":"<>","min":"2014-05-20","max":"2014-05-30"}]]}}]}`:                           "json: condition.and[0].or[0]: dateOfSession is only allowed as the first condition of a segment",
+		`{"version":1,"segments":[{"type":"condition","condition":{"and":[[{"target":"ga:a","operator":"==","value":"b"}]]}}]}`:                                                                                                                                                 "json: segments[0]: no segment scope (user:: or session::)",
+		`{"version":1,"segments":[{"scope":"users","type":"condition","condition":{"and":[[{"target":"ga:a","operator":"==","value":"b"}]]}},{"type":"reference","reference":"-1"}]}`:                                                                                        "json: segments[1]: gaid:: segment cannot be combined with other segments",
+	}
+	for in, expected := range table {
+		var ss Segments
+		if err := json.Unmarshal([]byte(in), &ss); err == nil || err.Error() != expected {
+			t.Errorf("%s: expected error %q, but %v", in, expected, err)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	b, err := ioutil.ReadFile("schema/segments.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Properties struct {
+			Version struct {
+				Const int `json:"const"`
+			} `json:"version"`
+		} `json:"properties"`
+		Defs map[string]struct {
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Properties.Version.Const != JSONSchemaVersion {
+		t.Errorf("schema version %d", schema.Properties.Version.Const)
+	}
+	values := func(m map[string]string) []string {
+		var vs []string
+		for _, v := range m {
+			vs = append(vs, v)
+		}
+		return vs
+	}
+	table := []struct {
+		def, property string
+		expected      []string
+	}{
+		{"segment", "scope", values(jsonScopes)},
+		{"segment", "type", values(jsonTypes)},
+		{"step", "type", values(jsonStepTypes)},
+		{"expression", "metricScope", values(jsonMetricScopes)},
+		{"expression", "operator", values(jsonOperators)},
+	}
+	for _, c := range table {
+		act := schema.Defs[c.def].Properties[c.property].Enum
+		sort.Strings(act)
+		sort.Strings(c.expected)
+		if !reflect.DeepEqual(act, c.expected) {
+			t.Errorf("%s.%s: expected %v, but %v", c.def, c.property, c.expected, act)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package gasegment
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIllegal
+	tokenScope
+	tokenSegmentType
+	tokenSegmentID
+	tokenNot
+	tokenFirstHit
+	tokenMetricScope
+	tokenTarget
+	tokenOperator
+	tokenValue
+	tokenSegmentSeparator
+	tokenStepSeparator
+	tokenAndSeparator
+	tokenOrSeparator
+)
+
+// token is a lexical element of a segment definition. text is the raw source text of src[pos:end].
+type token struct {
+	kind     tokenKind
+	pos, end int
+	text     string
+
+	// for tokenIllegal
+	message  string
+	expected []string
+}
+
+var (
+	segmentScopes = []SegmentScope{UserScope, SessionScope}
+	segmentTypes  = []SegmentType{ConditionSegment, SequenceSegment, ReferenceSegment}
+	metricScopes  = []MetricScope{PerHit, PerUser, PerSession, PerProduct}
+	stepTypes     = []SequenceStepType{Precedes, ImmediatelyPrecedes}
+
+	// ORDER IS IMPORTANT.
+	// e.g. using invalid ordering - such as `LessThan, LesthanEqual`, then, ">=" is matching at `>`(LessThan). NG
+	operators = []Operator{
+		Equal,
+		NotEqual,
+		LessThanEqual,
+		GreaterThanEqual,
+		NotBetween,
+		Between,
+		GreaterThan,
+		LessThan,
+		NotInList,
+		InList,
+		ContainsSubstring,
+		NotContainsSubstring,
+		Regexp,
+		NotRegexp,
+	}
+)
+
+type lexState int
+
+const (
+	lexSegment lexState = iota
+	lexCondition
+	lexSequence
+	lexReference
+	lexExpression
+	lexSeparator
+)
+
+type lexer struct {
+	src        string
+	pos        int
+	state      lexState
+	tokens     []token
+	allowSpace bool
+}
+
+// lex splits a definition into tokens in a single pass. Malformed parts are
+// reported as tokenIllegal and lexing resumes at the next separator.
+// The last token is always tokenEOF.
+func lex(src string) []token {
+	return lexWithOptions(src, false)
+}
+
+// lexWithOptions is lex, optionally skipping whitespace around separators.
+func lexWithOptions(src string, allowSpace bool) []token {
+	l := &lexer{src: src, allowSpace: allowSpace}
+	for {
+		switch l.state {
+		case lexSegment:
+			l.skipSpace()
+			l.lexSegment()
+		case lexCondition:
+			l.acceptString("!", tokenNot)
+			l.state = lexExpression
+		case lexSequence:
+			l.acceptString("!", tokenNot)
+			l.acceptString("^", tokenFirstHit)
+			l.state = lexExpression
+		case lexReference:
+			l.emit(tokenSegmentID, l.scanToSegmentSeparator(l.pos))
+			l.state = lexSeparator
+		case lexExpression:
+			l.lexExpression()
+		case lexSeparator:
+			l.skipSpace()
+			if l.pos >= len(l.src) {
+				l.emit(tokenEOF, l.pos)
+				return l.tokens
+			}
+			l.lexSeparator()
+		}
+	}
+}
+
+func (l *lexer) emit(kind tokenKind, end int) {
+	l.tokens = append(l.tokens, token{kind: kind, pos: l.pos, end: end, text: l.src[l.pos:end]})
+	l.pos = end
+}
+
+func (l *lexer) illegal(end int, message string, expected ...string) {
+	l.tokens = append(l.tokens, token{kind: tokenIllegal, pos: l.pos, end: end, text: l.src[l.pos:end], message: message, expected: expected})
+	l.pos = end
+}
+
+func (l *lexer) acceptString(s string, kind tokenKind) bool {
+	if strings.HasPrefix(l.src[l.pos:], s) {
+		l.emit(kind, l.pos+len(s))
+		return true
+	}
+	return false
+}
+
+func (l *lexer) lexSegment() {
+	hasScope := false
+	for _, sc := range segmentScopes {
+		if l.acceptString(sc.String(), tokenScope) {
+			hasScope = true
+			break
+		}
+	}
+	if l.acceptString(ConditionSegment.String(), tokenSegmentType) {
+		l.state = lexCondition
+		return
+	}
+	if l.acceptString(SequenceSegment.String(), tokenSegmentType) {
+		l.state = lexSequence
+		return
+	}
+	if l.acceptString(ReferenceSegment.String(), tokenSegmentType) {
+		l.state = lexReference
+		return
+	}
+	end := l.scanToSegmentSeparator(l.pos)
+	expected := []string{ExpectedSegmentType}
+	if !hasScope {
+		expected = append([]string{ExpectedScope}, expected...)
+	}
+	l.illegal(end, fmt.Sprintf("unknown segment condition %s", l.src[l.pos:end]), expected...)
+	l.state = lexSeparator
+}
+
+func (l *lexer) lexExpression() {
+	l.skipSpace()
+	start := l.pos
+	for _, ms := range metricScopes {
+		if l.acceptString(ms.String(), tokenMetricScope) {
+			break
+		}
+	}
+
+	// find the first operator before any separator
+	targetStart := l.pos
+	i := targetStart
+	for i < len(l.src) {
+		c := l.src[i]
+		if c == '\\' {
+			i += 2
+			continue
+		}
+		if c == ';' || c == ',' {
+			break
+		}
+		if op := operatorAt(l.src, i); op != "" {
+			if i == targetStart {
+				l.illegal(i+len(op), "empty dimension or metric", ExpectedTarget)
+				l.pos = l.scanValue(l.pos)
+			} else {
+				l.emit(tokenTarget, i)
+				l.emit(tokenOperator, i+len(op))
+				l.emit(tokenValue, l.trimSpace(l.pos, l.scanValue(l.pos)))
+			}
+			l.state = lexSeparator
+			return
+		}
+		i++
+	}
+	if i > len(l.src) {
+		i = len(l.src)
+	}
+	l.pos = targetStart
+	l.illegal(i, fmt.Sprintf("invalid expression: %s", l.src[start:i]), ExpectedOperator)
+	l.state = lexSeparator
+}
+
+func (l *lexer) lexSeparator() {
+	switch l.src[l.pos] {
+	case ',':
+		l.emit(tokenOrSeparator, l.pos+1)
+		l.state = lexExpression
+	case ';':
+		if isSegmentStart(l.lookahead(l.pos + 1)) {
+			l.emit(tokenSegmentSeparator, l.pos+1)
+			l.state = lexSegment
+			return
+		}
+		for _, st := range stepTypes {
+			if l.acceptString(st.String(), tokenStepSeparator) {
+				l.state = lexExpression
+				return
+			}
+		}
+		l.emit(tokenAndSeparator, l.pos+1)
+		l.state = lexExpression
+	default:
+		// unreachable: values and illegal tokens always stop at a separator
+		l.illegal(l.scanToSegmentSeparator(l.pos), fmt.Sprintf("unexpected %q", l.src[l.pos:l.pos+1]))
+	}
+}
+
+// scanValue returns the end of the value starting at i: the first unescaped ',' or ';'.
+func (l *lexer) scanValue(i int) int {
+	for i < len(l.src) {
+		switch l.src[i] {
+		case '\\':
+			i += 2
+			continue
+		case ';', ',':
+			return i
+		}
+		i++
+	}
+	return len(l.src)
+}
+
+// scanToSegmentSeparator returns the position of the next unescaped ';' starting a new segment.
+func (l *lexer) scanToSegmentSeparator(i int) int {
+	for i < len(l.src) {
+		switch l.src[i] {
+		case '\\':
+			i += 2
+			continue
+		case ';':
+			if isSegmentStart(l.lookahead(i + 1)) {
+				return i
+			}
+		}
+		i++
+	}
+	return len(l.src)
+}
+
+func (l *lexer) skipSpace() {
+	if !l.allowSpace {
+		return
+	}
+	for l.pos < len(l.src) && isSpace(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+// trimSpace returns the end of src[start:end] without trailing whitespace.
+func (l *lexer) trimSpace(start, end int) int {
+	if !l.allowSpace {
+		return end
+	}
+	for end > start && isSpace(l.src[end-1]) {
+		end--
+	}
+	return end
+}
+
+// lookahead returns the source from i, skipping whitespace if allowed.
+func (l *lexer) lookahead(i int) string {
+	if l.allowSpace {
+		for i < len(l.src) && isSpace(l.src[i]) {
+			i++
+		}
+	}
+	return l.src[i:]
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isSegmentStart(s string) bool {
+	for _, sc := range segmentScopes {
+		if strings.HasPrefix(s, sc.String()) {
+			s = s[len(sc.String()):]
+			break
+		}
+	}
+	for _, st := range segmentTypes {
+		if strings.HasPrefix(s, st.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func operatorAt(s string, i int) Operator {
+	for _, op := range operators {
+		if strings.HasPrefix(s[i:], op.String()) {
+			return op
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package gasegment
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits are the maximum sizes of a segment definition accepted by Google Analytics.
+// A zero field means no limit.
+type Limits struct {
+	// MaxDefinitionLength is the length of the whole definition in bytes.
+	MaxDefinitionLength int
+	// MaxConditions is the number of expressions in a segment.
+	MaxConditions int
+	// MaxInListValues is the number of items of a `[]` or `![]` expression.
+	MaxInListValues int
+	// MaxRegexpLength is the length of a `=~` or `!~` value in characters.
+	MaxRegexpLength int
+	// MaxSequenceSteps is the number of steps in a sequence segment.
+	MaxSequenceSteps int
+	// MaxDateOfSessionDays is the number of days of a dateOfSession range.
+	MaxDateOfSessionDays int
+}
+
+var (
+	StandardLimits = Limits{
+		MaxDefinitionLength:  1024,
+		MaxConditions:        10,
+		MaxInListValues:      10,
+		MaxRegexpLength:      128,
+		MaxSequenceSteps:     10,
+		MaxDateOfSessionDays: MaxDateOfSessionDays,
+	}
+	GA360Limits = Limits{
+		MaxDefinitionLength:  4096,
+		MaxConditions:        20,
+		MaxInListValues:      10,
+		MaxRegexpLength:      128,
+		MaxSequenceSteps:     10,
+		MaxDateOfSessionDays: MaxDateOfSessionDays,
+	}
+)
+
+// LimitViolation is a node exceeding one of the Limits.
+type LimitViolation struct {
+	// Path locates the node, e.g. `segments[0].sequence.steps[1].and[0].or[2]`.
+	Path  string
+	Limit string
+	Value int
+	Max   int
+}
+
+func (v LimitViolation) String() string {
+	return fmt.Sprintf("%s: %s %d exceeds the limit of %d", v.Path, v.Limit, v.Value, v.Max)
+}
+
+type LimitViolations []LimitViolation
+
+func (vs LimitViolations) Err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return &LimitError{Violations: vs}
+}
+
+// LimitError is the error of LimitViolations.
+type LimitError struct {
+	Violations LimitViolations
+}
+
+func (e *LimitError) Error() string {
+	if len(e.Violations) == 1 {
+		return e.Violations[0].String()
+	}
+	return fmt.Sprintf("%s (and %d more)", e.Violations[0], len(e.Violations)-1)
+}
+
+// CheckLimits checks the segments against StandardLimits.
+func (scs Segments) CheckLimits() LimitViolations {
+	return StandardLimits.Check(scs)
+}
+
+// Check returns the parts of the segments exceeding the limits.
+func (l Limits) Check(scs Segments) LimitViolations {
+	var vs LimitViolations
+	check := func(limit string, path Path, value, max int) {
+		if max > 0 && value > max {
+			vs = append(vs, LimitViolation{Path: path.String(), Limit: limit, Value: value, Max: max})
+		}
+	}
+	Inspect(scs, func(node Node, path Path) bool {
+		switch n := node.(type) {
+		case Segments:
+			check("definition length", path, len(n.DefString()), l.MaxDefinitionLength)
+		case Condition:
+			check("conditions", path, n.Groups().count(), l.MaxConditions)
+			if n.DateRange != nil {
+				check("dateOfSession days", path, n.DateRange.Days(), l.MaxDateOfSessionDays)
+			}
+		case SequenceStep:
+			if n.DateRange != nil {
+				check("dateOfSession days", path, n.DateRange.Days(), l.MaxDateOfSessionDays)
+			}
+		case Sequence:
+			count := 0
+			for _, step := range n.SequenceSteps {
+				count += step.Groups().count()
+			}
+			check("conditions", path, count, l.MaxConditions)
+			check("sequence steps", path, len(n.SequenceSteps), l.MaxSequenceSteps)
+		case Expression:
+			switch {
+			case n.IsList():
+				check("in-list values", path, len(SplitListValue(n.Value)), l.MaxInListValues)
+			case n.Operator == Regexp || n.Operator == NotRegexp:
+				check("regexp length", path, utf8.RuneCountInString(n.Value), l.MaxRegexpLength)
+			}
+		}
+		return true
+	})
+	return vs
+}
+
+func (ae AndExpression) count() int {
+	n := 0
+	for _, or := range ae {
+		n += len(or)
+	}
+	return n
+}
@@ -0,0 +1,65 @@
+package gasegment
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckLimits(t *testing.T) {
+	longRegexp := strings.Repeat("a", 129)
+	table := []struct {
+		definition string
+		limits     Limits
+		expected   []string
+	}{
+		{"users::condition::ga:pagePath[]/1|/2|/3|/4|/5|/6|/7|/8|/9|/10", StandardLimits, nil},
+		{
+			"users::condition::ga:pagePath==/a;ga:pagePath[]/1|/2|/3|/4|/5|/6|/7|/8|/9|/10|/11",
+			StandardLimits,
+			[]string{"segments[0].condition.and[1].or[0]: in-list values 11 exceeds the limit of 10"},
+		},
+		{
+			"users::sequence::ga:pagePath==/a;->>ga:pagePath==/b,ga:pagePath=~" + longRegexp,
+			StandardLimits,
+			[]string{"segments[0].sequence.steps[1].and[0].or[1]: regexp length 129 exceeds the limit of 128"},
+		},
+		{"users::condition::ga:pagePath=~" + longRegexp, Limits{}, nil},
+		{
+			"users::condition::ga:hits>1;ga:hits>2,ga:hits>3;sessions::sequence::ga:hits>1;->ga:hits>2;->ga:hits>3",
+			Limits{MaxConditions: 2, MaxSequenceSteps: 2, MaxDefinitionLength: 50},
+			[]string{
+				"segments: definition length 101 exceeds the limit of 50",
+				"segments[0].condition: conditions 3 exceeds the limit of 2",
+				"segments[1].sequence: conditions 3 exceeds the limit of 2",
+				"segments[1].sequence: sequence steps 3 exceeds the limit of 2",
+			},
+		},
+	}
+	for _, c := range table {
+		ss, _ := ParseWithDiagnostics(c.definition)
+		var act []string
+		for _, v := range c.limits.Check(ss) {
+			act = append(act, v.String())
+		}
+		if !reflect.DeepEqual(act, c.expected) {
+			t.Errorf("%s\n\texpected: %q\n\tactual:   %q", c.definition, c.expected, act)
+		}
+	}
+
+	ss := MustParse("users::condition::ga:pagePath==/a")
+	if err := ss.CheckLimits().Err(); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+
+	// the parser rejects such a range, so the segment is built directly
+	ss[0].Condition.SetDateOfSession(DateRange{Start: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC)})
+	vs := ss.CheckLimits()
+	if len(vs) != 1 || vs[0].String() != "segments[0].condition: dateOfSession days 32 exceeds the limit of 31" {
+		t.Errorf("unexpected violations %v", vs)
+	}
+	if _, ok := vs.Err().(*LimitError); !ok {
+		t.Errorf("unexpected error %v", vs.Err())
+	}
+}
@@ -0,0 +1,945 @@
+// Code generated by gencolumns from files/columns.json. DO NOT EDIT.
+
+// Package metrics has the metrics of the Core Reporting API metadata.
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/wacul/gasegment"
+)
+
+// Metric is a metric with its metadata. Its methods build the expressions
+// allowed on a metric, e.g. metrics.Sessions.PerUser().Gt(5).
+type Metric struct {
+	ID                gasegment.DimensionOrMetric
+	DataType          string
+	AllowedInSegments bool
+
+	target gasegment.Target
+}
+
+// ProductMetric is a metric which also accepts the perProduct:: scope.
+type ProductMetric struct {
+	Metric
+}
+
+func metric(id, dataType string, allowedInSegments bool) Metric {
+	return Metric{
+		ID:                gasegment.DimensionOrMetric(id),
+		DataType:          dataType,
+		AllowedInSegments: allowedInSegments,
+		target:            gasegment.Metric(id),
+	}
+}
+
+func productMetric(id, dataType string, allowedInSegments bool) ProductMetric {
+	return ProductMetric{metric(id, dataType, allowedInSegments)}
+}
+
+func (m Metric) PerHit() Metric {
+	m.target = m.target.PerHit()
+	return m
+}
+
+func (m Metric) PerSession() Metric {
+	m.target = m.target.PerSession()
+	return m
+}
+
+func (m Metric) PerUser() Metric {
+	m.target = m.target.PerUser()
+	return m
+}
+
+func (m ProductMetric) PerProduct() Metric {
+	m.target = m.target.PerProduct()
+	return m.Metric
+}
+
+func (m Metric) Eq(v float64) gasegment.Cond {
+	return m.target.Eq(gasegment.FormatNumber(v))
+}
+
+func (m Metric) Ne(v float64) gasegment.Cond {
+	return m.target.Ne(gasegment.FormatNumber(v))
+}
+
+func (m Metric) Lt(v float64) gasegment.Cond {
+	return m.target.Lt(v)
+}
+
+func (m Metric) Le(v float64) gasegment.Cond {
+	return m.target.Le(v)
+}
+
+func (m Metric) Gt(v float64) gasegment.Cond {
+	return m.target.Gt(v)
+}
+
+func (m Metric) Ge(v float64) gasegment.Cond {
+	return m.target.Ge(v)
+}
+
+// Between matches the numbers greater than min and less than max (`<>`).
+func (m Metric) Between(min, max float64) gasegment.Cond {
+	return m.target.Between(min, max)
+}
+
+// NotBetween matches the numbers not matched by Between (`!<>`).
+func (m Metric) NotBetween(min, max float64) gasegment.Cond {
+	return m.target.NotBetween(min, max)
+}
+
+// AdClicks is ga:adClicks, "Clicks".
+var AdClicks = metric("ga:adClicks", "INTEGER", false)
+
+// AdCost is ga:adCost, "Cost".
+var AdCost = metric("ga:adCost", "CURRENCY", false)
+
+// AdsenseAdUnitsViewed is ga:adsenseAdUnitsViewed, "AdSense Ad Units Viewed".
+var AdsenseAdUnitsViewed = metric("ga:adsenseAdUnitsViewed", "INTEGER", true)
+
+// AdsenseAdsClicks is ga:adsenseAdsClicks, "AdSense Ads Clicked".
+var AdsenseAdsClicks = metric("ga:adsenseAdsClicks", "INTEGER", true)
+
+// AdsenseAdsViewed is ga:adsenseAdsViewed, "AdSense Impressions".
+var AdsenseAdsViewed = metric("ga:adsenseAdsViewed", "INTEGER", true)
+
+// AdsenseCTR is ga:adsenseCTR, "AdSense CTR".
+var AdsenseCTR = metric("ga:adsenseCTR", "PERCENT", false)
+
+// AdsenseCoverage is ga:adsenseCoverage, "AdSense Coverage".
+var AdsenseCoverage = metric("ga:adsenseCoverage", "PERCENT", false)
+
+// AdsenseECPM is ga:adsenseECPM, "AdSense eCPM".
+var AdsenseECPM = metric("ga:adsenseECPM", "CURRENCY", false)
+
+// AdsenseExits is ga:adsenseExits, "AdSense Exits".
+var AdsenseExits = metric("ga:adsenseExits", "INTEGER", true)
+
+// AdsensePageImpressions is ga:adsensePageImpressions, "AdSense Page Impressions".
+var AdsensePageImpressions = metric("ga:adsensePageImpressions", "INTEGER", true)
+
+// AdsenseRevenue is ga:adsenseRevenue, "AdSense Revenue".
+var AdsenseRevenue = metric("ga:adsenseRevenue", "CURRENCY", true)
+
+// AdsenseViewableImpressionPercent is ga:adsenseViewableImpressionPercent, "AdSense Viewable Impression %".
+var AdsenseViewableImpressionPercent = metric("ga:adsenseViewableImpressionPercent", "PERCENT", false)
+
+// AdxCTR is ga:adxCTR, "AdX CTR".
+var AdxCTR = metric("ga:adxCTR", "PERCENT", false)
+
+// AdxClicks is ga:adxClicks, "AdX Clicks".
+var AdxClicks = metric("ga:adxClicks", "INTEGER", true)
+
+// AdxCoverage is ga:adxCoverage, "AdX Coverage".
+var AdxCoverage = metric("ga:adxCoverage", "PERCENT", false)
+
+// AdxECPM is ga:adxECPM, "AdX eCPM".
+var AdxECPM = metric("ga:adxECPM", "CURRENCY", false)
+
+// AdxImpressions is ga:adxImpressions, "AdX Impressions".
+var AdxImpressions = metric("ga:adxImpressions", "INTEGER", true)
+
+// AdxImpressionsPerSession is ga:adxImpressionsPerSession, "AdX Impressions / Session".
+var AdxImpressionsPerSession = metric("ga:adxImpressionsPerSession", "FLOAT", false)
+
+// AdxMonetizedPageviews is ga:adxMonetizedPageviews, "AdX Monetized Pageviews".
+var AdxMonetizedPageviews = metric("ga:adxMonetizedPageviews", "INTEGER", true)
+
+// AdxRevenue is ga:adxRevenue, "AdX Revenue".
+var AdxRevenue = metric("ga:adxRevenue", "CURRENCY", true)
+
+// AdxRevenuePer1000Sessions is ga:adxRevenuePer1000Sessions, "AdX Revenue / 1000 Sessions".
+var AdxRevenuePer1000Sessions = metric("ga:adxRevenuePer1000Sessions", "CURRENCY", false)
+
+// AdxViewableImpressionsPercent is ga:adxViewableImpressionsPercent, "AdX Viewable Impressions %".
+var AdxViewableImpressionsPercent = metric("ga:adxViewableImpressionsPercent", "PERCENT", false)
+
+// Appviews is ga:appviews, "Screen Views".
+//
+// Deprecated: use Screenviews instead.
+var Appviews = metric("ga:appviews", "INTEGER", true)
+
+// AppviewsPerVisit is ga:appviewsPerVisit, "Screens / Session".
+//
+// Deprecated: use ScreenviewsPerSession instead.
+var AppviewsPerVisit = metric("ga:appviewsPerVisit", "FLOAT", false)
+
+// AvgDomContentLoadedTime is ga:avgDomContentLoadedTime, "Avg. Document Content Loaded Time (sec)".
+var AvgDomContentLoadedTime = metric("ga:avgDomContentLoadedTime", "FLOAT", false)
+
+// AvgDomInteractiveTime is ga:avgDomInteractiveTime, "Avg. Document Interactive Time (sec)".
+var AvgDomInteractiveTime = metric("ga:avgDomInteractiveTime", "FLOAT", false)
+
+// AvgDomainLookupTime is ga:avgDomainLookupTime, "Avg. Domain Lookup Time (sec)".
+var AvgDomainLookupTime = metric("ga:avgDomainLookupTime", "FLOAT", false)
+
+// AvgEventValue is ga:avgEventValue, "Avg. Value".
+var AvgEventValue = metric("ga:avgEventValue", "FLOAT", false)
+
+// AvgPageDownloadTime is ga:avgPageDownloadTime, "Avg. Page Download Time (sec)".
+var AvgPageDownloadTime = metric("ga:avgPageDownloadTime", "FLOAT", false)
+
+// AvgPageLoadTime is ga:avgPageLoadTime, "Avg. Page Load Time (sec)".
+var AvgPageLoadTime = metric("ga:avgPageLoadTime", "FLOAT", false)
+
+// AvgRedirectionTime is ga:avgRedirectionTime, "Avg. Redirection Time (sec)".
+var AvgRedirectionTime = metric("ga:avgRedirectionTime", "FLOAT", false)
+
+// AvgScreenviewDuration is ga:avgScreenviewDuration, "Avg. Time on Screen".
+var AvgScreenviewDuration = metric("ga:avgScreenviewDuration", "TIME", false)
+
+// AvgSearchDepth is ga:avgSearchDepth, "Average Search Depth".
+var AvgSearchDepth = metric("ga:avgSearchDepth", "FLOAT", false)
+
+// AvgSearchDuration is ga:avgSearchDuration, "Time after Search".
+var AvgSearchDuration = metric("ga:avgSearchDuration", "TIME", false)
+
+// AvgSearchResultViews is ga:avgSearchResultViews, "Results Pageviews / Search".
+var AvgSearchResultViews = metric("ga:avgSearchResultViews", "FLOAT", false)
+
+// AvgServerConnectionTime is ga:avgServerConnectionTime, "Avg. Server Connection Time (sec)".
+var AvgServerConnectionTime = metric("ga:avgServerConnectionTime", "FLOAT", false)
+
+// AvgServerResponseTime is ga:avgServerResponseTime, "Avg. Server Response Time (sec)".
+var AvgServerResponseTime = metric("ga:avgServerResponseTime", "FLOAT", false)
+
+// AvgSessionDuration is ga:avgSessionDuration, "Avg. Session Duration".
+var AvgSessionDuration = metric("ga:avgSessionDuration", "TIME", false)
+
+// AvgTimeOnPage is ga:avgTimeOnPage, "Avg. Time on Page".
+var AvgTimeOnPage = metric("ga:avgTimeOnPage", "TIME", false)
+
+// AvgTimeOnSite is ga:avgTimeOnSite, "Avg. Session Duration".
+//
+// Deprecated: use AvgSessionDuration instead.
+var AvgTimeOnSite = metric("ga:avgTimeOnSite", "TIME", false)
+
+// AvgUserTimingValue is ga:avgUserTimingValue, "Avg. User Timing (sec)".
+var AvgUserTimingValue = metric("ga:avgUserTimingValue", "FLOAT", false)
+
+// BackfillCTR is ga:backfillCTR, "DFP Backfill CTR".
+var BackfillCTR = metric("ga:backfillCTR", "PERCENT", false)
+
+// BackfillClicks is ga:backfillClicks, "DFP Backfill Clicks".
+var BackfillClicks = metric("ga:backfillClicks", "INTEGER", false)
+
+// BackfillCoverage is ga:backfillCoverage, "DFP Backfill Coverage".
+var BackfillCoverage = metric("ga:backfillCoverage", "PERCENT", false)
+
+// BackfillECPM is ga:backfillECPM, "DFP Backfill eCPM".
+var BackfillECPM = metric("ga:backfillECPM", "CURRENCY", false)
+
+// BackfillImpressions is ga:backfillImpressions, "DFP Backfill Impressions".
+var BackfillImpressions = metric("ga:backfillImpressions", "INTEGER", false)
+
+// BackfillImpressionsPerSession is ga:backfillImpressionsPerSession, "DFP Backfill Impressions / Session".
+var BackfillImpressionsPerSession = metric("ga:backfillImpressionsPerSession", "FLOAT", false)
+
+// BackfillMonetizedPageviews is ga:backfillMonetizedPageviews, "DFP Backfill Monetized Pageviews".
+var BackfillMonetizedPageviews = metric("ga:backfillMonetizedPageviews", "INTEGER", false)
+
+// BackfillRevenue is ga:backfillRevenue, "DFP Backfill Revenue".
+var BackfillRevenue = metric("ga:backfillRevenue", "CURRENCY", false)
+
+// BackfillRevenuePer1000Sessions is ga:backfillRevenuePer1000Sessions, "DFP Backfill Revenue / 1000 Sessions".
+var BackfillRevenuePer1000Sessions = metric("ga:backfillRevenuePer1000Sessions", "CURRENCY", false)
+
+// BackfillViewableImpressionsPercent is ga:backfillViewableImpressionsPercent, "DFP Backfill Viewable Impressions %".
+var BackfillViewableImpressionsPercent = metric("ga:backfillViewableImpressionsPercent", "PERCENT", false)
+
+// BounceRate is ga:bounceRate, "Bounce Rate".
+var BounceRate = metric("ga:bounceRate", "PERCENT", false)
+
+// Bounces is ga:bounces, "Bounces".
+var Bounces = metric("ga:bounces", "INTEGER", true)
+
+// BuyToDetailRate is ga:buyToDetailRate, "Buy-to-Detail Rate".
+var BuyToDetailRate = metric("ga:buyToDetailRate", "PERCENT", false)
+
+// CPC is ga:CPC, "CPC".
+var CPC = metric("ga:CPC", "CURRENCY", false)
+
+// CPM is ga:CPM, "CPM".
+var CPM = metric("ga:CPM", "CURRENCY", false)
+
+// CTR is ga:CTR, "CTR".
+var CTR = metric("ga:CTR", "PERCENT", false)
+
+// CalcMetricNAME is ga:calcMetric_<NAME>, "Calculated Metric".
+var CalcMetricNAME = metric("ga:calcMetric_<NAME>", "INTEGER", false)
+
+// CartToDetailRate is ga:cartToDetailRate, "Cart-to-Detail Rate".
+var CartToDetailRate = metric("ga:cartToDetailRate", "PERCENT", false)
+
+// CohortActiveUsers is ga:cohortActiveUsers, "Users".
+var CohortActiveUsers = metric("ga:cohortActiveUsers", "INTEGER", false)
+
+// CohortAppviewsPerUser is ga:cohortAppviewsPerUser, "Appviews per User".
+var CohortAppviewsPerUser = metric("ga:cohortAppviewsPerUser", "FLOAT", false)
+
+// CohortAppviewsPerUserWithLifetimeCriteria is ga:cohortAppviewsPerUserWithLifetimeCriteria, "Appviews Per User (LTV)".
+var CohortAppviewsPerUserWithLifetimeCriteria = metric("ga:cohortAppviewsPerUserWithLifetimeCriteria", "FLOAT", false)
+
+// CohortGoalCompletionsPerUser is ga:cohortGoalCompletionsPerUser, "Goal Completions per User".
+var CohortGoalCompletionsPerUser = metric("ga:cohortGoalCompletionsPerUser", "FLOAT", false)
+
+// CohortGoalCompletionsPerUserWithLifetimeCriteria is ga:cohortGoalCompletionsPerUserWithLifetimeCriteria, "Goal Completions Per User (LTV)".
+var CohortGoalCompletionsPerUserWithLifetimeCriteria = metric("ga:cohortGoalCompletionsPerUserWithLifetimeCriteria", "FLOAT", false)
+
+// CohortPageviewsPerUser is ga:cohortPageviewsPerUser, "Pageviews per User".
+var CohortPageviewsPerUser = metric("ga:cohortPageviewsPerUser", "FLOAT", false)
+
+// CohortPageviewsPerUserWithLifetimeCriteria is ga:cohortPageviewsPerUserWithLifetimeCriteria, "Pageviews Per User (LTV)".
+var CohortPageviewsPerUserWithLifetimeCriteria = metric("ga:cohortPageviewsPerUserWithLifetimeCriteria", "FLOAT", false)
+
+// CohortRetentionRate is ga:cohortRetentionRate, "User Retention".
+var CohortRetentionRate = metric("ga:cohortRetentionRate", "PERCENT", false)
+
+// CohortRevenuePerUser is ga:cohortRevenuePerUser, "Revenue per User".
+var CohortRevenuePerUser = metric("ga:cohortRevenuePerUser", "CURRENCY", false)
+
+// CohortRevenuePerUserWithLifetimeCriteria is ga:cohortRevenuePerUserWithLifetimeCriteria, "Revenue Per User (LTV)".
+var CohortRevenuePerUserWithLifetimeCriteria = metric("ga:cohortRevenuePerUserWithLifetimeCriteria", "CURRENCY", false)
+
+// CohortSessionDurationPerUser is ga:cohortSessionDurationPerUser, "Session Duration per User".
+var CohortSessionDurationPerUser = metric("ga:cohortSessionDurationPerUser", "TIME", false)
+
+// CohortSessionDurationPerUserWithLifetimeCriteria is ga:cohortSessionDurationPerUserWithLifetimeCriteria, "Session Duration Per User (LTV)".
+var CohortSessionDurationPerUserWithLifetimeCriteria = metric("ga:cohortSessionDurationPerUserWithLifetimeCriteria", "TIME", false)
+
+// CohortSessionsPerUser is ga:cohortSessionsPerUser, "Sessions per User".
+var CohortSessionsPerUser = metric("ga:cohortSessionsPerUser", "FLOAT", false)
+
+// CohortSessionsPerUserWithLifetimeCriteria is ga:cohortSessionsPerUserWithLifetimeCriteria, "Sessions Per User (LTV)".
+var CohortSessionsPerUserWithLifetimeCriteria = metric("ga:cohortSessionsPerUserWithLifetimeCriteria", "FLOAT", false)
+
+// CohortTotalUsers is ga:cohortTotalUsers, "Total Users".
+var CohortTotalUsers = metric("ga:cohortTotalUsers", "INTEGER", false)
+
+// CohortTotalUsersWithLifetimeCriteria is ga:cohortTotalUsersWithLifetimeCriteria, "Users".
+var CohortTotalUsersWithLifetimeCriteria = metric("ga:cohortTotalUsersWithLifetimeCriteria", "INTEGER", false)
+
+// CorrelationScore is ga:correlationScore, "Correlation Score".
+var CorrelationScore = metric("ga:correlationScore", "CURRENCY", false)
+
+// CostPerConversion is ga:costPerConversion, "Cost per Conversion".
+var CostPerConversion = metric("ga:costPerConversion", "CURRENCY", false)
+
+// CostPerGoalConversion is ga:costPerGoalConversion, "Cost per Goal Conversion".
+var CostPerGoalConversion = metric("ga:costPerGoalConversion", "CURRENCY", false)
+
+// CostPerTransaction is ga:costPerTransaction, "Cost per Transaction".
+var CostPerTransaction = metric("ga:costPerTransaction", "CURRENCY", false)
+
+// CustomMetric returns ga:metricXX, "Custom Metric XX Value", for n from 1 to 20 (200 on GA360).
+func CustomMetric(n int) Metric {
+	return metric("ga:metric"+strconv.Itoa(n), "INTEGER", true)
+}
+
+// DbmCPA is ga:dbmCPA, "DBM eCPA".
+var DbmCPA = metric("ga:dbmCPA", "CURRENCY", false)
+
+// DbmCPC is ga:dbmCPC, "DBM eCPC".
+var DbmCPC = metric("ga:dbmCPC", "CURRENCY", false)
+
+// DbmCPM is ga:dbmCPM, "DBM eCPM".
+var DbmCPM = metric("ga:dbmCPM", "CURRENCY", false)
+
+// DbmCTR is ga:dbmCTR, "DBM CTR".
+var DbmCTR = metric("ga:dbmCTR", "PERCENT", false)
+
+// DbmClicks is ga:dbmClicks, "DBM Clicks".
+var DbmClicks = metric("ga:dbmClicks", "INTEGER", false)
+
+// DbmConversions is ga:dbmConversions, "DBM Conversions".
+var DbmConversions = metric("ga:dbmConversions", "INTEGER", false)
+
+// DbmCost is ga:dbmCost, "DBM Cost".
+var DbmCost = metric("ga:dbmCost", "CURRENCY", false)
+
+// DbmImpressions is ga:dbmImpressions, "DBM Impressions".
+var DbmImpressions = metric("ga:dbmImpressions", "INTEGER", false)
+
+// DbmROAS is ga:dbmROAS, "DBM ROAS".
+var DbmROAS = metric("ga:dbmROAS", "PERCENT", false)
+
+// DcmCPC is ga:dcmCPC, "DFA CPC".
+var DcmCPC = metric("ga:dcmCPC", "CURRENCY", false)
+
+// DcmCTR is ga:dcmCTR, "DFA CTR".
+var DcmCTR = metric("ga:dcmCTR", "PERCENT", false)
+
+// DcmClicks is ga:dcmClicks, "DFA Clicks".
+var DcmClicks = metric("ga:dcmClicks", "INTEGER", false)
+
+// DcmCost is ga:dcmCost, "DFA Cost".
+var DcmCost = metric("ga:dcmCost", "CURRENCY", false)
+
+// DcmFloodlightQuantity is ga:dcmFloodlightQuantity, "DFA Conversions".
+var DcmFloodlightQuantity = metric("ga:dcmFloodlightQuantity", "INTEGER", false)
+
+// DcmFloodlightRevenue is ga:dcmFloodlightRevenue, "DFA Revenue".
+var DcmFloodlightRevenue = metric("ga:dcmFloodlightRevenue", "CURRENCY", false)
+
+// DcmImpressions is ga:dcmImpressions, "DFA Impressions".
+var DcmImpressions = metric("ga:dcmImpressions", "INTEGER", false)
+
+// DcmMargin is ga:dcmMargin, "DFA Margin".
+//
+// Deprecated: no longer supported.
+var DcmMargin = metric("ga:dcmMargin", "PERCENT", false)
+
+// DcmROAS is ga:dcmROAS, "DFA ROAS".
+var DcmROAS = metric("ga:dcmROAS", "PERCENT", false)
+
+// DcmROI is ga:dcmROI, "DFA ROI".
+//
+// Deprecated: no longer supported.
+var DcmROI = metric("ga:dcmROI", "PERCENT", false)
+
+// DcmRPC is ga:dcmRPC, "DFA RPC".
+var DcmRPC = metric("ga:dcmRPC", "CURRENCY", false)
+
+// DfpCTR is ga:dfpCTR, "DFP CTR".
+var DfpCTR = metric("ga:dfpCTR", "PERCENT", false)
+
+// DfpClicks is ga:dfpClicks, "DFP Clicks".
+var DfpClicks = metric("ga:dfpClicks", "INTEGER", false)
+
+// DfpCoverage is ga:dfpCoverage, "DFP Coverage".
+var DfpCoverage = metric("ga:dfpCoverage", "PERCENT", false)
+
+// DfpECPM is ga:dfpECPM, "DFP eCPM".
+var DfpECPM = metric("ga:dfpECPM", "CURRENCY", false)
+
+// DfpImpressions is ga:dfpImpressions, "DFP Impressions".
+var DfpImpressions = metric("ga:dfpImpressions", "INTEGER", false)
+
+// DfpImpressionsPerSession is ga:dfpImpressionsPerSession, "DFP Impressions / Session".
+var DfpImpressionsPerSession = metric("ga:dfpImpressionsPerSession", "FLOAT", false)
+
+// DfpMonetizedPageviews is ga:dfpMonetizedPageviews, "DFP Monetized Pageviews".
+var DfpMonetizedPageviews = metric("ga:dfpMonetizedPageviews", "INTEGER", false)
+
+// DfpRevenue is ga:dfpRevenue, "DFP Revenue".
+var DfpRevenue = metric("ga:dfpRevenue", "CURRENCY", false)
+
+// DfpRevenuePer1000Sessions is ga:dfpRevenuePer1000Sessions, "DFP Revenue / 1000 Sessions".
+var DfpRevenuePer1000Sessions = metric("ga:dfpRevenuePer1000Sessions", "CURRENCY", false)
+
+// DfpViewableImpressionsPercent is ga:dfpViewableImpressionsPercent, "DFP Viewable Impressions %".
+var DfpViewableImpressionsPercent = metric("ga:dfpViewableImpressionsPercent", "PERCENT", false)
+
+// DomContentLoadedTime is ga:domContentLoadedTime, "Document Content Loaded Time (ms)".
+var DomContentLoadedTime = metric("ga:domContentLoadedTime", "INTEGER", false)
+
+// DomInteractiveTime is ga:domInteractiveTime, "Document Interactive Time (ms)".
+var DomInteractiveTime = metric("ga:domInteractiveTime", "INTEGER", false)
+
+// DomLatencyMetricsSample is ga:domLatencyMetricsSample, "DOM Latency Metrics Sample".
+var DomLatencyMetricsSample = metric("ga:domLatencyMetricsSample", "INTEGER", false)
+
+// DomainLookupTime is ga:domainLookupTime, "Domain Lookup Time (ms)".
+var DomainLookupTime = metric("ga:domainLookupTime", "INTEGER", false)
+
+// DsCPC is ga:dsCPC, "DS CPC".
+var DsCPC = metric("ga:dsCPC", "CURRENCY", false)
+
+// DsCTR is ga:dsCTR, "DS CTR".
+var DsCTR = metric("ga:dsCTR", "PERCENT", false)
+
+// DsClicks is ga:dsClicks, "DS Clicks".
+var DsClicks = metric("ga:dsClicks", "INTEGER", false)
+
+// DsCost is ga:dsCost, "DS Cost".
+var DsCost = metric("ga:dsCost", "CURRENCY", false)
+
+// DsImpressions is ga:dsImpressions, "DS Impressions".
+var DsImpressions = metric("ga:dsImpressions", "INTEGER", false)
+
+// DsProfit is ga:dsProfit, "DS Profit".
+var DsProfit = metric("ga:dsProfit", "CURRENCY", false)
+
+// DsReturnOnAdSpend is ga:dsReturnOnAdSpend, "DS ROAS".
+var DsReturnOnAdSpend = metric("ga:dsReturnOnAdSpend", "PERCENT", false)
+
+// DsRevenuePerClick is ga:dsRevenuePerClick, "DS RPC".
+var DsRevenuePerClick = metric("ga:dsRevenuePerClick", "CURRENCY", false)
+
+// EntranceBounceRate is ga:entranceBounceRate, "Bounce Rate".
+//
+// Deprecated: use BounceRate instead.
+var EntranceBounceRate = metric("ga:entranceBounceRate", "PERCENT", false)
+
+// EntranceRate is ga:entranceRate, "Entrances / Pageviews".
+var EntranceRate = metric("ga:entranceRate", "PERCENT", false)
+
+// Entrances is ga:entrances, "Entrances".
+var Entrances = metric("ga:entrances", "INTEGER", true)
+
+// EventValue is ga:eventValue, "Event Value".
+var EventValue = metric("ga:eventValue", "INTEGER", true)
+
+// EventsPerSessionWithEvent is ga:eventsPerSessionWithEvent, "Events / Session with Event".
+var EventsPerSessionWithEvent = metric("ga:eventsPerSessionWithEvent", "FLOAT", false)
+
+// EventsPerVisitWithEvent is ga:eventsPerVisitWithEvent, "Events / Session with Event".
+//
+// Deprecated: use EventsPerSessionWithEvent instead.
+var EventsPerVisitWithEvent = metric("ga:eventsPerVisitWithEvent", "FLOAT", false)
+
+// Exceptions is ga:exceptions, "Exceptions".
+var Exceptions = metric("ga:exceptions", "INTEGER", true)
+
+// ExceptionsPerScreenview is ga:exceptionsPerScreenview, "Exceptions / Screen".
+var ExceptionsPerScreenview = metric("ga:exceptionsPerScreenview", "PERCENT", false)
+
+// ExitRate is ga:exitRate, "% Exit".
+var ExitRate = metric("ga:exitRate", "PERCENT", false)
+
+// Exits is ga:exits, "Exits".
+var Exits = metric("ga:exits", "INTEGER", true)
+
+// FatalExceptions is ga:fatalExceptions, "Crashes".
+var FatalExceptions = metric("ga:fatalExceptions", "INTEGER", true)
+
+// FatalExceptionsPerScreenview is ga:fatalExceptionsPerScreenview, "Crashes / Screen".
+var FatalExceptionsPerScreenview = metric("ga:fatalExceptionsPerScreenview", "PERCENT", false)
+
+// FourteenDayUsers is ga:14dayUsers, "14 Day Active Users".
+var FourteenDayUsers = metric("ga:14dayUsers", "INTEGER", false)
+
+// GoalAbandonRateAll is ga:goalAbandonRateAll, "Total Abandonment Rate".
+var GoalAbandonRateAll = metric("ga:goalAbandonRateAll", "PERCENT", false)
+
+// GoalAbandonedFunnels returns ga:goalXXAbandons, "Goal XX Abandoned Funnels", for n from 1 to 20.
+func GoalAbandonedFunnels(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"Abandons", "INTEGER", false)
+}
+
+// GoalAbandonmentRate returns ga:goalXXAbandonRate, "Goal XX Abandonment Rate", for n from 1 to 20.
+func GoalAbandonmentRate(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"AbandonRate", "PERCENT", false)
+}
+
+// GoalAbandonsAll is ga:goalAbandonsAll, "Abandoned Funnels".
+var GoalAbandonsAll = metric("ga:goalAbandonsAll", "INTEGER", false)
+
+// GoalCompletions returns ga:goalXXCompletions, "Goal XX Completions", for n from 1 to 20.
+func GoalCompletions(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"Completions", "INTEGER", true)
+}
+
+// GoalCompletionsAll is ga:goalCompletionsAll, "Goal Completions".
+var GoalCompletionsAll = metric("ga:goalCompletionsAll", "INTEGER", true)
+
+// GoalConversionRate returns ga:goalXXConversionRate, "Goal XX Conversion Rate", for n from 1 to 20.
+func GoalConversionRate(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"ConversionRate", "PERCENT", false)
+}
+
+// GoalConversionRateAll is ga:goalConversionRateAll, "Goal Conversion Rate".
+var GoalConversionRateAll = metric("ga:goalConversionRateAll", "PERCENT", false)
+
+// GoalStarts returns ga:goalXXStarts, "Goal XX Starts", for n from 1 to 20.
+func GoalStarts(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"Starts", "INTEGER", true)
+}
+
+// GoalStartsAll is ga:goalStartsAll, "Goal Starts".
+var GoalStartsAll = metric("ga:goalStartsAll", "INTEGER", true)
+
+// GoalValue returns ga:goalXXValue, "Goal XX Value", for n from 1 to 20.
+func GoalValue(n int) Metric {
+	return metric("ga:goal"+strconv.Itoa(n)+"Value", "CURRENCY", true)
+}
+
+// GoalValueAll is ga:goalValueAll, "Goal Value".
+var GoalValueAll = metric("ga:goalValueAll", "CURRENCY", true)
+
+// GoalValueAllPerSearch is ga:goalValueAllPerSearch, "Per Search Goal Value".
+var GoalValueAllPerSearch = metric("ga:goalValueAllPerSearch", "CURRENCY", false)
+
+// GoalValuePerSession is ga:goalValuePerSession, "Per Session Goal Value".
+var GoalValuePerSession = metric("ga:goalValuePerSession", "CURRENCY", false)
+
+// GoalValuePerVisit is ga:goalValuePerVisit, "Per Session Goal Value".
+//
+// Deprecated: use GoalValuePerSession instead.
+var GoalValuePerVisit = metric("ga:goalValuePerVisit", "CURRENCY", false)
+
+// Hits is ga:hits, "Hits".
+var Hits = metric("ga:hits", "INTEGER", true)
+
+// Impressions is ga:impressions, "Impressions".
+var Impressions = metric("ga:impressions", "INTEGER", false)
+
+// InternalPromotionCTR is ga:internalPromotionCTR, "Internal Promotion CTR".
+var InternalPromotionCTR = metric("ga:internalPromotionCTR", "PERCENT", false)
+
+// InternalPromotionClicks is ga:internalPromotionClicks, "Internal Promotion Clicks".
+var InternalPromotionClicks = metric("ga:internalPromotionClicks", "INTEGER", true)
+
+// InternalPromotionViews is ga:internalPromotionViews, "Internal Promotion Views".
+var InternalPromotionViews = metric("ga:internalPromotionViews", "INTEGER", true)
+
+// ItemQuantity is ga:itemQuantity, "Quantity".
+var ItemQuantity = productMetric("ga:itemQuantity", "INTEGER", true)
+
+// ItemRevenue is ga:itemRevenue, "Product Revenue".
+var ItemRevenue = productMetric("ga:itemRevenue", "CURRENCY", true)
+
+// ItemsPerPurchase is ga:itemsPerPurchase, "Average QTY".
+var ItemsPerPurchase = metric("ga:itemsPerPurchase", "FLOAT", false)
+
+// LocalItemRevenue is ga:localItemRevenue, "Local Product Revenue".
+var LocalItemRevenue = productMetric("ga:localItemRevenue", "CURRENCY", true)
+
+// LocalProductRefundAmount is ga:localProductRefundAmount, "Local Product Refund Amount".
+var LocalProductRefundAmount = productMetric("ga:localProductRefundAmount", "CURRENCY", true)
+
+// LocalRefundAmount is ga:localRefundAmount, "Local Refund Amount".
+var LocalRefundAmount = metric("ga:localRefundAmount", "CURRENCY", true)
+
+// LocalTransactionRevenue is ga:localTransactionRevenue, "Local Revenue".
+var LocalTransactionRevenue = metric("ga:localTransactionRevenue", "CURRENCY", false)
+
+// LocalTransactionShipping is ga:localTransactionShipping, "Local Shipping".
+var LocalTransactionShipping = metric("ga:localTransactionShipping", "CURRENCY", false)
+
+// LocalTransactionTax is ga:localTransactionTax, "Local Tax".
+var LocalTransactionTax = metric("ga:localTransactionTax", "CURRENCY", false)
+
+// Margin is ga:margin, "Margin".
+//
+// Deprecated: no longer supported.
+var Margin = metric("ga:margin", "PERCENT", false)
+
+// NewUsers is ga:newUsers, "New Users".
+var NewUsers = metric("ga:newUsers", "INTEGER", true)
+
+// NewVisits is ga:newVisits, "New Users".
+//
+// Deprecated: use NewUsers instead.
+var NewVisits = metric("ga:newVisits", "INTEGER", true)
+
+// OneDayUsers is ga:1dayUsers, "1 Day Active Users".
+var OneDayUsers = metric("ga:1dayUsers", "INTEGER", false)
+
+// OrganicSearches is ga:organicSearches, "Organic Searches".
+var OrganicSearches = metric("ga:organicSearches", "INTEGER", false)
+
+// PageDownloadTime is ga:pageDownloadTime, "Page Download Time (ms)".
+var PageDownloadTime = metric("ga:pageDownloadTime", "INTEGER", false)
+
+// PageLoadSample is ga:pageLoadSample, "Page Load Sample".
+var PageLoadSample = metric("ga:pageLoadSample", "INTEGER", false)
+
+// PageLoadTime is ga:pageLoadTime, "Page Load Time (ms)".
+var PageLoadTime = metric("ga:pageLoadTime", "INTEGER", false)
+
+// PageValue is ga:pageValue, "Page Value".
+var PageValue = metric("ga:pageValue", "CURRENCY", false)
+
+// Pageviews is ga:pageviews, "Pageviews".
+var Pageviews = metric("ga:pageviews", "INTEGER", true)
+
+// PageviewsPerSession is ga:pageviewsPerSession, "Pages / Session".
+var PageviewsPerSession = metric("ga:pageviewsPerSession", "FLOAT", false)
+
+// PageviewsPerVisit is ga:pageviewsPerVisit, "Pages / Session".
+//
+// Deprecated: use PageviewsPerSession instead.
+var PageviewsPerVisit = metric("ga:pageviewsPerVisit", "FLOAT", false)
+
+// PercentNewSessions is ga:percentNewSessions, "% New Sessions".
+var PercentNewSessions = metric("ga:percentNewSessions", "PERCENT", false)
+
+// PercentNewVisits is ga:percentNewVisits, "% New Sessions".
+//
+// Deprecated: use PercentNewSessions instead.
+var PercentNewVisits = metric("ga:percentNewVisits", "PERCENT", false)
+
+// PercentSearchRefinements is ga:percentSearchRefinements, "% Search Refinements".
+var PercentSearchRefinements = metric("ga:percentSearchRefinements", "PERCENT", false)
+
+// PercentSessionsWithSearch is ga:percentSessionsWithSearch, "% Sessions with Search".
+var PercentSessionsWithSearch = metric("ga:percentSessionsWithSearch", "PERCENT", false)
+
+// PercentVisitsWithSearch is ga:percentVisitsWithSearch, "% Sessions with Search".
+//
+// Deprecated: use PercentSessionsWithSearch instead.
+var PercentVisitsWithSearch = metric("ga:percentVisitsWithSearch", "PERCENT", false)
+
+// ProductAddsToCart is ga:productAddsToCart, "Product Adds To Cart".
+var ProductAddsToCart = productMetric("ga:productAddsToCart", "INTEGER", true)
+
+// ProductCheckouts is ga:productCheckouts, "Product Checkouts".
+var ProductCheckouts = productMetric("ga:productCheckouts", "INTEGER", true)
+
+// ProductDetailViews is ga:productDetailViews, "Product Detail Views".
+var ProductDetailViews = productMetric("ga:productDetailViews", "INTEGER", true)
+
+// ProductListCTR is ga:productListCTR, "Product List CTR".
+var ProductListCTR = metric("ga:productListCTR", "PERCENT", false)
+
+// ProductListClicks is ga:productListClicks, "Product List Clicks".
+var ProductListClicks = productMetric("ga:productListClicks", "INTEGER", true)
+
+// ProductListViews is ga:productListViews, "Product List Views".
+var ProductListViews = productMetric("ga:productListViews", "INTEGER", true)
+
+// ProductRefundAmount is ga:productRefundAmount, "Product Refund Amount".
+var ProductRefundAmount = productMetric("ga:productRefundAmount", "CURRENCY", true)
+
+// ProductRefunds is ga:productRefunds, "Product Refunds".
+var ProductRefunds = productMetric("ga:productRefunds", "INTEGER", true)
+
+// ProductRemovesFromCart is ga:productRemovesFromCart, "Product Removes From Cart".
+var ProductRemovesFromCart = productMetric("ga:productRemovesFromCart", "INTEGER", true)
+
+// ProductRevenuePerPurchase is ga:productRevenuePerPurchase, "Product Revenue per Purchase".
+var ProductRevenuePerPurchase = metric("ga:productRevenuePerPurchase", "CURRENCY", false)
+
+// QuantityAddedToCart is ga:quantityAddedToCart, "Quantity Added To Cart".
+var QuantityAddedToCart = productMetric("ga:quantityAddedToCart", "INTEGER", true)
+
+// QuantityCheckedOut is ga:quantityCheckedOut, "Quantity Checked Out".
+var QuantityCheckedOut = productMetric("ga:quantityCheckedOut", "INTEGER", true)
+
+// QuantityRefunded is ga:quantityRefunded, "Quantity Refunded".
+var QuantityRefunded = productMetric("ga:quantityRefunded", "INTEGER", true)
+
+// QuantityRemovedFromCart is ga:quantityRemovedFromCart, "Quantity Removed From Cart".
+var QuantityRemovedFromCart = productMetric("ga:quantityRemovedFromCart", "INTEGER", true)
+
+// QueryProductQuantity is ga:queryProductQuantity, "Queried Product Quantity".
+var QueryProductQuantity = metric("ga:queryProductQuantity", "INTEGER", false)
+
+// ROAS is ga:ROAS, "ROAS".
+var ROAS = metric("ga:ROAS", "PERCENT", false)
+
+// ROI is ga:ROI, "ROI".
+//
+// Deprecated: no longer supported.
+var ROI = metric("ga:ROI", "PERCENT", false)
+
+// RPC is ga:RPC, "RPC".
+var RPC = metric("ga:RPC", "CURRENCY", false)
+
+// RedirectionTime is ga:redirectionTime, "Redirection Time (ms)".
+var RedirectionTime = metric("ga:redirectionTime", "INTEGER", false)
+
+// RefundAmount is ga:refundAmount, "Refund Amount".
+var RefundAmount = metric("ga:refundAmount", "CURRENCY", true)
+
+// RelatedProductQuantity is ga:relatedProductQuantity, "Related Product Quantity".
+var RelatedProductQuantity = metric("ga:relatedProductQuantity", "INTEGER", false)
+
+// RevenuePerItem is ga:revenuePerItem, "Average Price".
+var RevenuePerItem = metric("ga:revenuePerItem", "CURRENCY", false)
+
+// RevenuePerTransaction is ga:revenuePerTransaction, "Average Order Value".
+var RevenuePerTransaction = metric("ga:revenuePerTransaction", "CURRENCY", false)
+
+// RevenuePerUser is ga:revenuePerUser, "Revenue per User".
+var RevenuePerUser = metric("ga:revenuePerUser", "CURRENCY", false)
+
+// Screenviews is ga:screenviews, "Screen Views".
+var Screenviews = metric("ga:screenviews", "INTEGER", true)
+
+// ScreenviewsPerSession is ga:screenviewsPerSession, "Screens / Session".
+var ScreenviewsPerSession = metric("ga:screenviewsPerSession", "FLOAT", false)
+
+// SearchDepth is ga:searchDepth, "Search Depth".
+var SearchDepth = metric("ga:searchDepth", "INTEGER", true)
+
+// SearchDuration is ga:searchDuration, "Time after Search".
+var SearchDuration = metric("ga:searchDuration", "TIME", true)
+
+// SearchExitRate is ga:searchExitRate, "% Search Exits".
+var SearchExitRate = metric("ga:searchExitRate", "PERCENT", false)
+
+// SearchExits is ga:searchExits, "Search Exits".
+var SearchExits = metric("ga:searchExits", "INTEGER", true)
+
+// SearchGoalConversionRateAll is ga:searchGoalConversionRateAll, "Site Search Goal Conversion Rate".
+var SearchGoalConversionRateAll = metric("ga:searchGoalConversionRateAll", "PERCENT", false)
+
+// SearchRefinements is ga:searchRefinements, "Search Refinements".
+var SearchRefinements = metric("ga:searchRefinements", "INTEGER", true)
+
+// SearchResultViews is ga:searchResultViews, "Results Pageviews".
+var SearchResultViews = metric("ga:searchResultViews", "INTEGER", false)
+
+// SearchSessions is ga:searchSessions, "Sessions with Search".
+var SearchSessions = metric("ga:searchSessions", "INTEGER", true)
+
+// SearchUniques is ga:searchUniques, "Total Unique Searches".
+var SearchUniques = metric("ga:searchUniques", "INTEGER", true)
+
+// SearchVisits is ga:searchVisits, "Sessions with Search".
+//
+// Deprecated: use SearchSessions instead.
+var SearchVisits = metric("ga:searchVisits", "INTEGER", true)
+
+// ServerConnectionTime is ga:serverConnectionTime, "Server Connection Time (ms)".
+var ServerConnectionTime = metric("ga:serverConnectionTime", "INTEGER", false)
+
+// ServerResponseTime is ga:serverResponseTime, "Server Response Time (ms)".
+var ServerResponseTime = metric("ga:serverResponseTime", "INTEGER", false)
+
+// SessionDuration is ga:sessionDuration, "Session Duration".
+var SessionDuration = metric("ga:sessionDuration", "TIME", true)
+
+// Sessions is ga:sessions, "Sessions".
+var Sessions = metric("ga:sessions", "INTEGER", true)
+
+// SessionsPerUser is ga:sessionsPerUser, "Number of Sessions per User".
+var SessionsPerUser = metric("ga:sessionsPerUser", "FLOAT", false)
+
+// SessionsWithEvent is ga:sessionsWithEvent, "Sessions with Event".
+var SessionsWithEvent = metric("ga:sessionsWithEvent", "INTEGER", true)
+
+// SevenDayUsers is ga:7dayUsers, "7 Day Active Users".
+var SevenDayUsers = metric("ga:7dayUsers", "INTEGER", false)
+
+// SiteSearchGoalConversionRate returns ga:searchGoalXXConversionRate, "Site Search Goal XX Conversion Rate", for n from 1 to 20.
+func SiteSearchGoalConversionRate(n int) Metric {
+	return metric("ga:searchGoal"+strconv.Itoa(n)+"ConversionRate", "PERCENT", false)
+}
+
+// SocialActivities is ga:socialActivities, "Data Hub Activities".
+//
+// Deprecated: no longer supported.
+var SocialActivities = metric("ga:socialActivities", "INTEGER", false)
+
+// SocialInteractions is ga:socialInteractions, "Social Actions".
+var SocialInteractions = metric("ga:socialInteractions", "INTEGER", false)
+
+// SocialInteractionsPerSession is ga:socialInteractionsPerSession, "Actions Per Social Session".
+var SocialInteractionsPerSession = metric("ga:socialInteractionsPerSession", "FLOAT", false)
+
+// SocialInteractionsPerVisit is ga:socialInteractionsPerVisit, "Actions Per Social Session".
+//
+// Deprecated: use SocialInteractionsPerSession instead.
+var SocialInteractionsPerVisit = metric("ga:socialInteractionsPerVisit", "FLOAT", false)
+
+// SpeedMetricsSample is ga:speedMetricsSample, "Speed Metrics Sample".
+var SpeedMetricsSample = metric("ga:speedMetricsSample", "INTEGER", false)
+
+// ThirtyDayUsers is ga:30dayUsers, "30 Day Active Users".
+var ThirtyDayUsers = metric("ga:30dayUsers", "INTEGER", false)
+
+// TimeOnPage is ga:timeOnPage, "Time on Page".
+var TimeOnPage = metric("ga:timeOnPage", "TIME", true)
+
+// TimeOnScreen is ga:timeOnScreen, "Time on Screen".
+var TimeOnScreen = metric("ga:timeOnScreen", "TIME", true)
+
+// TimeOnSite is ga:timeOnSite, "Session Duration".
+//
+// Deprecated: use SessionDuration instead.
+var TimeOnSite = metric("ga:timeOnSite", "TIME", true)
+
+// TotalEvents is ga:totalEvents, "Total Events".
+var TotalEvents = metric("ga:totalEvents", "INTEGER", true)
+
+// TotalRefunds is ga:totalRefunds, "Refunds".
+var TotalRefunds = metric("ga:totalRefunds", "INTEGER", true)
+
+// TotalValue is ga:totalValue, "Total Value".
+var TotalValue = metric("ga:totalValue", "CURRENCY", false)
+
+// TransactionRevenue is ga:transactionRevenue, "Revenue".
+var TransactionRevenue = metric("ga:transactionRevenue", "CURRENCY", true)
+
+// TransactionRevenuePerSession is ga:transactionRevenuePerSession, "Per Session Value".
+var TransactionRevenuePerSession = metric("ga:transactionRevenuePerSession", "CURRENCY", false)
+
+// TransactionRevenuePerVisit is ga:transactionRevenuePerVisit, "Per Session Value".
+//
+// Deprecated: use TransactionRevenuePerSession instead.
+var TransactionRevenuePerVisit = metric("ga:transactionRevenuePerVisit", "CURRENCY", false)
+
+// TransactionShipping is ga:transactionShipping, "Shipping".
+var TransactionShipping = metric("ga:transactionShipping", "CURRENCY", true)
+
+// TransactionTax is ga:transactionTax, "Tax".
+var TransactionTax = metric("ga:transactionTax", "CURRENCY", true)
+
+// Transactions is ga:transactions, "Transactions".
+var Transactions = metric("ga:transactions", "INTEGER", true)
+
+// TransactionsPerSession is ga:transactionsPerSession, "Ecommerce Conversion Rate".
+var TransactionsPerSession = metric("ga:transactionsPerSession", "PERCENT", false)
+
+// TransactionsPerUser is ga:transactionsPerUser, "Transactions per User".
+var TransactionsPerUser = metric("ga:transactionsPerUser", "FLOAT", false)
+
+// TransactionsPerVisit is ga:transactionsPerVisit, "Ecommerce Conversion Rate".
+//
+// Deprecated: use TransactionsPerSession instead.
+var TransactionsPerVisit = metric("ga:transactionsPerVisit", "PERCENT", false)
+
+// UniqueAppviews is ga:uniqueAppviews, "Unique Screen Views".
+//
+// Deprecated: use UniqueScreenviews instead.
+var UniqueAppviews = metric("ga:uniqueAppviews", "INTEGER", true)
+
+// UniqueDimensionCombinations is ga:uniqueDimensionCombinations, "Unique Dimension Combinations".
+var UniqueDimensionCombinations = metric("ga:uniqueDimensionCombinations", "INTEGER", false)
+
+// UniqueEvents is ga:uniqueEvents, "Unique Events".
+//
+// Deprecated: use UniqueDimensionCombinations instead.
+var UniqueEvents = metric("ga:uniqueEvents", "INTEGER", false)
+
+// UniquePageviews is ga:uniquePageviews, "Unique Pageviews".
+var UniquePageviews = metric("ga:uniquePageviews", "INTEGER", true)
+
+// UniquePurchases is ga:uniquePurchases, "Unique Purchases".
+var UniquePurchases = productMetric("ga:uniquePurchases", "INTEGER", true)
+
+// UniqueScreenviews is ga:uniqueScreenviews, "Unique Screen Views".
+var UniqueScreenviews = metric("ga:uniqueScreenviews", "INTEGER", true)
+
+// UniqueSocialInteractions is ga:uniqueSocialInteractions, "Unique Social Actions".
+var UniqueSocialInteractions = metric("ga:uniqueSocialInteractions", "INTEGER", false)
+
+// UniqueViews returns ga:contentGroupUniqueViewsXX, "Unique Views XX", for n from 1 to 5.
+func UniqueViews(n int) Metric {
+	return metric("ga:contentGroupUniqueViews"+strconv.Itoa(n), "INTEGER", false)
+}
+
+// UserTimingSample is ga:userTimingSample, "User Timing Sample".
+var UserTimingSample = metric("ga:userTimingSample", "INTEGER", false)
+
+// UserTimingValue is ga:userTimingValue, "User Timing (ms)".
+var UserTimingValue = metric("ga:userTimingValue", "INTEGER", false)
+
+// Users is ga:users, "Users".
+var Users = metric("ga:users", "INTEGER", false)
+
+// VisitBounceRate is ga:visitBounceRate, "Bounce Rate".
+//
+// Deprecated: use BounceRate instead.
+var VisitBounceRate = metric("ga:visitBounceRate", "PERCENT", false)
+
+// Visitors is ga:visitors, "Users".
+//
+// Deprecated: use Users instead.
+var Visitors = metric("ga:visitors", "INTEGER", false)
+
+// Visits is ga:visits, "Sessions".
+//
+// Deprecated: use Sessions instead.
+var Visits = metric("ga:visits", "INTEGER", true)
+
+// VisitsWithEvent is ga:visitsWithEvent, "Sessions with Event".
+//
+// Deprecated: use SessionsWithEvent instead.
+var VisitsWithEvent = metric("ga:visitsWithEvent", "INTEGER", true)
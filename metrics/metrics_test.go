@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wacul/gasegment"
+	"github.com/wacul/gasegment/metrics"
+)
+
+func TestMetric(t *testing.T) {
+	if metrics.Sessions.ID != "ga:sessions" || metrics.Sessions.DataType != "INTEGER" || !metrics.Sessions.AllowedInSegments {
+		t.Errorf("unexpected %#v", metrics.Sessions)
+	}
+	for _, name := range []string{"Contains", "Matches", "In", "PerProduct"} {
+		if _, ok := reflect.TypeOf(metrics.Metric{}).MethodByName(name); ok {
+			t.Errorf("Metric must not have %s", name)
+		}
+	}
+	if _, ok := reflect.TypeOf(metrics.ItemRevenue).MethodByName("PerProduct"); !ok {
+		t.Error("ga:itemRevenue is product scoped")
+	}
+
+	ss, err := gasegment.Users().Condition().
+		Where(metrics.Sessions.PerUser().Gt(5)).
+		And(metrics.Hits.Eq(1.5)).
+		And(metrics.ItemRevenue.PerProduct().Between(100, 200)).
+		And(metrics.GoalCompletions(3).PerSession().NotBetween(1, 2)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "users::condition::perUser::ga:sessions>5;ga:hits==1.5;perProduct::ga:itemRevenue<>100_200;perSession::ga:goal3Completions!<>1_2"
+	if ss.DefString() != expected {
+		t.Errorf("expected %s, but %s", expected, ss.DefString())
+	}
+}
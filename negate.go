@@ -0,0 +1,155 @@
+package gasegment
+
+// maxDistributedGroups caps the size of an AndExpression made by distributing
+// OR over AND (Negate, Union), which grows as the product of the sizes of the groups.
+const maxDistributedGroups = 64
+
+var negatedOperators = map[Operator]Operator{
+	Equal:                NotEqual,
+	NotEqual:             Equal,
+	LessThan:             GreaterThanEqual,
+	GreaterThanEqual:     LessThan,
+	GreaterThan:          LessThanEqual,
+	LessThanEqual:        GreaterThan,
+	Between:              NotBetween,
+	NotBetween:           Between,
+	InList:               NotInList,
+	NotInList:            InList,
+	ContainsSubstring:    NotContainsSubstring,
+	NotContainsSubstring: ContainsSubstring,
+	Regexp:               NotRegexp,
+	NotRegexp:            Regexp,
+}
+
+// Negate returns the expression matching exactly the hits the expression doesn't match,
+// e.g. `ga:hits>1` for `ga:hits<=1`. A dateOfSession expression can't be negated.
+func (c Expression) Negate() (Expression, bool) {
+	op, ok := negatedOperators[c.Operator]
+	if !ok || c.IsDateOfSession() {
+		return Expression{}, false
+	}
+	c.Operator = op
+	c.Source = nil
+	return c, true
+}
+
+// Negate returns the AND of the negated terms (De Morgan's law).
+func (o OrExpression) Negate() (AndExpression, bool) {
+	if len(o) == 0 {
+		return nil, false
+	}
+	result := make(AndExpression, len(o))
+	for i, e := range o {
+		ne, ok := e.Negate()
+		if !ok {
+			return nil, false
+		}
+		result[i] = OrExpression{ne}
+	}
+	return result, true
+}
+
+// Negate returns the AND of the ORs of the negated terms, one from each group
+// (De Morgan's law and distribution), without duplicated groups. It fails when
+// the result would have more than 64 groups.
+func (a AndExpression) Negate() (AndExpression, bool) {
+	if len(a) == 0 {
+		return nil, false
+	}
+	size := 1
+	negated := make([]AndExpression, len(a))
+	for i, or := range a {
+		n, ok := or.Negate()
+		if !ok {
+			return nil, false
+		}
+		negated[i] = n
+		if size *= len(n); size > maxDistributedGroups {
+			return nil, false
+		}
+	}
+
+	// !(a1,a2;b1) = (!a1;!a2),(!b1) = (!a1,!b1);(!a2,!b1)
+	result := AndExpression{nil}
+	for _, n := range negated {
+		next := make(AndExpression, 0, len(result)*len(n))
+		for _, prefix := range result {
+			for _, single := range n {
+				next = append(next, append(append(OrExpression{}, prefix...), single[0]))
+			}
+		}
+		result = next
+	}
+
+	unique := make(AndExpression, 0, len(result))
+	seen := map[string]bool{}
+	for _, or := range result {
+		or = or.dedupe()
+		if key := or.DefString(); !seen[key] {
+			seen[key] = true
+			unique = append(unique, or)
+		}
+	}
+	return unique, true
+}
+
+func (o OrExpression) dedupe() OrExpression {
+	result := make(OrExpression, 0, len(o))
+	seen := map[string]bool{}
+	for _, e := range o {
+		if key := e.DefString(); !seen[key] {
+			seen[key] = true
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Negate returns the segment matching exactly the users or sessions the segment doesn't match.
+//
+// A condition is evaluated over all the hits of a user or a session, so negating its
+// expressions doesn't negate the segment in general: `sessions::condition::ga:pagePath!=/a`
+// is not the complement of `sessions::condition::ga:pagePath==/a`. The condition is
+// negated by De Morgan's law only when every expression is on a metric of the scope of
+// the segment (perUser:: for users::, perSession:: for sessions::) and there is no
+// dateOfSession; otherwise Exclude
+// (or Sequence.Not) is toggled. A reference segment can't be negated.
+func (sc Segment) Negate() (Segment, bool) {
+	sc.Source = nil
+	switch sc.Type {
+	case ConditionSegment:
+		if !sc.Condition.Exclude && sc.Condition.DateRange == nil && sc.Condition.AndExpression.onlyScopedMetrics(sc.Scope) {
+			if n, ok := sc.Condition.AndExpression.Negate(); ok {
+				sc.Condition.AndExpression = n
+				return sc, true
+			}
+		}
+		sc.Condition.Exclude = !sc.Condition.Exclude
+		return sc, true
+	case SequenceSegment:
+		sc.Sequence.Not = !sc.Sequence.Not
+		return sc, true
+	}
+	return Segment{}, false
+}
+
+// onlyScopedMetrics reports whether every expression is on a metric aggregated over the scope.
+func (a AndExpression) onlyScopedMetrics(scope SegmentScope) bool {
+	var ms MetricScope
+	switch scope {
+	case UserScope:
+		ms = PerUser
+	case SessionScope:
+		ms = PerSession
+	default:
+		return false
+	}
+	for _, or := range a {
+		for _, e := range or {
+			if e.MetricScope != ms {
+				return false
+			}
+		}
+	}
+	return true
+}
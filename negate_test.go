@@ -0,0 +1,183 @@
+package gasegment
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hit is a row of dimension and metric values an expression is evaluated against.
+type hit map[DimensionOrMetric]string
+
+func (h hit) match(e Expression) bool {
+	v := h[e.Target]
+	num := func(s string) float64 {
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	}
+	switch e.Operator {
+	case Equal:
+		return v == e.Value
+	case NotEqual:
+		return v != e.Value
+	case LessThan:
+		return num(v) < num(e.Value)
+	case LessThanEqual:
+		return num(v) <= num(e.Value)
+	case GreaterThan:
+		return num(v) > num(e.Value)
+	case GreaterThanEqual:
+		return num(v) >= num(e.Value)
+	case Between, NotBetween:
+		min, max, _ := e.NumberRange()
+		return (num(v) >= min && num(v) <= max) == (e.Operator == Between)
+	case InList, NotInList:
+		items, _ := e.List()
+		in := false
+		for _, item := range items {
+			in = in || item == v
+		}
+		return in == (e.Operator == InList)
+	case ContainsSubstring:
+		return strings.Contains(v, e.Value)
+	case NotContainsSubstring:
+		return !strings.Contains(v, e.Value)
+	case Regexp:
+		return regexp.MustCompile(e.Value).MatchString(v)
+	case NotRegexp:
+		return !regexp.MustCompile(e.Value).MatchString(v)
+	}
+	panic("unknown operator " + e.Operator)
+}
+
+func (h hit) matchOr(o OrExpression) bool {
+	for _, e := range o {
+		if h.match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h hit) matchAnd(a AndExpression) bool {
+	for _, o := range a {
+		if !h.matchOr(o) {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHit(r *rand.Rand) hit {
+	return hit{
+		"ga:hits":     strconv.Itoa(r.Intn(6)),
+		"ga:pagePath": []string{"/a", "/b", "/ab"}[r.Intn(3)],
+	}
+}
+
+func randomExpression(r *rand.Rand) Expression {
+	if r.Intn(2) == 0 {
+		ops := []Operator{Equal, NotEqual, LessThan, LessThanEqual, GreaterThan, GreaterThanEqual}
+		e := Expression{Target: "ga:hits", Operator: ops[r.Intn(len(ops))], Value: strconv.Itoa(r.Intn(6))}
+		if r.Intn(4) == 0 {
+			e = NewNumberBetween("ga:hits", float64(r.Intn(3)), float64(2+r.Intn(3)))
+		}
+		return e
+	}
+	values := []string{"/a", "/b", "a"}
+	switch r.Intn(4) {
+	case 0:
+		return Expression{Target: "ga:pagePath", Operator: Equal, Value: values[r.Intn(3)]}
+	case 1:
+		return Expression{Target: "ga:pagePath", Operator: ContainsSubstring, Value: values[r.Intn(3)]}
+	case 2:
+		return Expression{Target: "ga:pagePath", Operator: Regexp, Value: "^" + values[r.Intn(3)] + "$"}
+	default:
+		return NewInList("ga:pagePath", values[:1+r.Intn(3)]...)
+	}
+}
+
+func randomAndExpression(r *rand.Rand) AndExpression {
+	a := make(AndExpression, 1+r.Intn(3))
+	for i := range a {
+		a[i] = make(OrExpression, 1+r.Intn(3))
+		for j := range a[i] {
+			a[i][j] = randomExpression(r)
+		}
+	}
+	return a
+}
+
+func TestNegateProperty(t *testing.T) {
+	r := newRand()
+	for n := 0; n < 500; n++ {
+		a := randomAndExpression(r)
+		na, ok := a.Negate()
+		if !ok {
+			t.Fatalf("%s: can't be negated", a.DefString())
+		}
+		no, _ := a[0].Negate()
+		ne, _ := a[0][0].Negate()
+		for m := 0; m < 50; m++ {
+			h := randomHit(r)
+			if h.match(ne) == h.match(a[0][0]) {
+				t.Errorf("%v: %s and %s", h, a[0][0].DefString(), ne.DefString())
+			}
+			if h.matchAnd(no) == h.matchOr(a[0]) {
+				t.Errorf("%v: %s and %s", h, a[0].DefString(), no.DefString())
+			}
+			if h.matchAnd(na) == h.matchAnd(a) {
+				t.Errorf("%v: %s and %s", h, a.DefString(), na.DefString())
+			}
+		}
+		if nna, _ := na.Negate(); len(nna) > 0 {
+			for m := 0; m < 50; m++ {
+				if h := randomHit(r); h.matchAnd(nna) != h.matchAnd(a) {
+					t.Errorf("%v: %s and %s", h, a.DefString(), nna.DefString())
+				}
+			}
+		}
+	}
+}
+
+func TestNegate(t *testing.T) {
+	table := []struct {
+		definition string
+		expected   string
+	}{
+		{"users::condition::ga:pagePath==/a", "users::condition::!ga:pagePath==/a"},
+		{"users::condition::!ga:pagePath==/a;ga:hits>1", "users::condition::ga:pagePath==/a;ga:hits>1"},
+		{"sessions::sequence::ga:pagePath==/a;->ga:pagePath==/b", "sessions::sequence::!ga:pagePath==/a;->ga:pagePath==/b"},
+		{"users::sequence::!ga:pagePath==/a", "users::sequence::ga:pagePath==/a"},
+		{"users::condition::perUser::ga:sessions>5", "users::condition::perUser::ga:sessions<=5"},
+		{"sessions::condition::perSession::ga:hits<1,perSession::ga:hits>10", "sessions::condition::perSession::ga:hits>=1;perSession::ga:hits<=10"},
+		{"users::condition::perUser::ga:sessions>5;perUser::ga:hits<1,perUser::ga:hits>=10", "users::condition::perUser::ga:sessions<=5,perUser::ga:hits>=1;perUser::ga:sessions<=5,perUser::ga:hits<10"},
+		// perSession:: metrics in a users:: segment are aggregated over sessions
+		{"users::condition::perSession::ga:hits>1", "users::condition::!perSession::ga:hits>1"},
+	}
+	for _, c := range table {
+		ss := MustParse(c.definition)
+		n, ok := ss[0].Negate()
+		if !ok {
+			t.Errorf("%s: can't be negated", c.definition)
+			continue
+		}
+		if act := NewSegments(n).DefString(); act != c.expected {
+			t.Errorf("%s\n\texpected: %s\n\tactual:   %s", c.definition, c.expected, act)
+		}
+	}
+
+	if _, ok := NewSegmentRef("-1").Negate(); ok {
+		t.Error("reference must not be negated")
+	}
+	if _, ok := NewDateOfSession(time.Now(), time.Now()).Negate(); ok {
+		t.Error("dateOfSession must not be negated")
+	}
+}
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
@@ -0,0 +1,67 @@
+package gasegment
+
+import "sort"
+
+// Normalize returns the canonical form of the segments, so that segments meaning
+// the same have the same DefString.
+//
+// Every segment gets its scope explicitly, condition segments which are not
+// excluded are merged per scope unless both have a DateRange (a segment has at
+// most one dateOfSession), AND groups and OR terms are sorted and
+// deduplicated, list values are sorted and deduplicated, escapes are made
+// canonical, and empty and duplicate segments are removed. The order of sequence
+// steps is kept. Source is not kept.
+func (scs Segments) Normalize() Segments {
+	var result Segments
+	merged := map[SegmentScope]int{}
+	var scope SegmentScope
+	for _, sg := range scs {
+		if sg.Scope != "" {
+			scope = sg.Scope
+		}
+		sg.Source = nil
+		switch sg.Type {
+		case ConditionSegment:
+			if i, ok := merged[scope]; ok && !sg.Condition.Exclude &&
+				(result[i].Condition.DateRange == nil || sg.Condition.DateRange == nil) {
+				ae := append(result[i].Condition.AndExpression, sg.Condition.AndExpression...)
+				result[i].Condition.AndExpression = ae.canonical()
+				if result[i].Condition.DateRange == nil {
+					result[i].Condition.DateRange = sg.Condition.DateRange.clone()
+				}
+				continue
+			}
+			ae := sg.Condition.AndExpression.canonical()
+			if len(ae) == 0 && sg.Condition.DateRange == nil {
+				continue
+			}
+			result = append(result, Segment{Scope: scope, Type: sg.Type, Condition: Condition{Exclude: sg.Condition.Exclude, DateRange: sg.Condition.DateRange.clone(), AndExpression: ae}})
+			if _, ok := merged[scope]; !ok && !sg.Condition.Exclude {
+				merged[scope] = len(result) - 1
+			}
+		case SequenceSegment:
+			if len(sg.Sequence.SequenceSteps) == 0 {
+				continue
+			}
+			result = append(result, Segment{Scope: scope, Type: sg.Type, Sequence: sg.Sequence.canonical()})
+		case ReferenceSegment:
+			result = append(result, Segment{Type: sg.Type, Reference: sg.Reference})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		si, sj := scopeSortMap[result[i].Scope], scopeSortMap[result[j].Scope]
+		if si != sj {
+			return si < sj
+		}
+		return result[i].DefStringWithoutScope() < result[j].DefStringWithoutScope()
+	})
+	unique := result[:0]
+	for i, sg := range result {
+		if i > 0 && sg.DefString() == result[i-1].DefString() {
+			continue
+		}
+		unique = append(unique, sg)
+	}
+	return unique
+}
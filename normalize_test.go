@@ -0,0 +1,59 @@
+package gasegment
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	table := []struct {
+		definition string
+		expected   string
+	}{
+		{
+			"users::condition::ga:pagePath==/b,ga:pagePath==/a,ga:pagePath==/b;ga:hits>1",
+			"users::condition::ga:hits>1;ga:pagePath==/a,ga:pagePath==/b",
+		},
+		{
+			// merged per scope, excluded conditions are kept apart
+			"sessions::condition::ga:hits>1;users::condition::ga:pagePath==/a;sessions::condition::!ga:hits>5;condition::ga:medium==cpc;condition::!ga:hits>5",
+			"users::condition::ga:pagePath==/a;sessions::condition::!ga:hits>5;condition::ga:hits>1;ga:medium==cpc",
+		},
+		{
+			// sequence steps keep their order
+			"users::sequence::ga:pagePath==/b,ga:pagePath==/a;->>ga:hits>1;ga:hits<0;->ga:pagePath==/a;users::sequence::ga:pagePath==/a,ga:pagePath==/b;->>ga:hits<0;ga:hits>1;->ga:pagePath==/a",
+			"users::sequence::ga:pagePath==/a,ga:pagePath==/b;->>ga:hits<0;ga:hits>1;->ga:pagePath==/a",
+		},
+		{
+			`users::condition::ga:pagePath[]/b|/a|\/b;ga:pagePath<>\a_b;ga:pagePath==/a\\b`,
+			`users::condition::ga:pagePath<>a_b;ga:pagePath==/a\b;ga:pagePath[]/a|/b`,
+		},
+		{"gaid::-3", "gaid::-3"},
+		{
+			// dateOfSession is allowed only once in a segment
+			"users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1;users::condition::dateOfSession<>2014-05-01_2014-05-10;condition::ga:pagePath==/a",
+			"users::condition::dateOfSession<>2014-05-01_2014-05-10;condition::dateOfSession<>2014-05-20_2014-05-30;ga:hits>1;ga:pagePath==/a",
+		},
+	}
+	for _, c := range table {
+		ss := MustParse(c.definition)
+		n := ss.Normalize()
+		if act := n.DefString(); act != c.expected {
+			t.Errorf("%s\n\texpected: %s\n\tactual:   %s", c.definition, c.expected, act)
+		}
+		if act := n.Normalize().DefString(); act != c.expected {
+			t.Errorf("%s: normalize is not idempotent: %s", c.definition, act)
+		}
+		if _, err := Parse(n.DefString()); err != nil {
+			t.Errorf("%s: normalized form does not parse: %s", c.definition, err)
+		}
+		if !MustParse(c.expected).SemanticEqual(ss) {
+			t.Errorf("%s: must be semantically equal to %s", c.definition, c.expected)
+		}
+	}
+
+	for _, def := range TestCheckDefs {
+		ss := MustParse(def)
+		n := MustParse(ss.Normalize().DefString())
+		if !n.Equal(n.Normalize()) {
+			t.Errorf("%s: normalized form does not parse to itself", def)
+		}
+	}
+}
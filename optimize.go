@@ -0,0 +1,354 @@
+package gasegment
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	RuleDuplicate = "remove duplicate"
+	RuleInList    = "merge equalities into in-list"
+	RuleBetween   = "merge comparisons into range"
+	RuleTautology = "remove tautology"
+)
+
+// OptimizationStep is a rewrite made by OptimizeWithSteps.
+type OptimizationStep struct {
+	// Path locates the rewritten AND or OR group in the input (see Path).
+	Path   string
+	Rule   string
+	Before string
+	After  string
+}
+
+func (s OptimizationStep) String() string {
+	return fmt.Sprintf("%s: %s: %s => %s", s.Path, s.Rule, s.Before, s.After)
+}
+
+// Optimize returns equivalent segments with a shorter definition. See OptimizeWithSteps.
+func Optimize(scs Segments) Segments {
+	result, _ := OptimizeWithSteps(scs)
+	return result
+}
+
+// OptimizeWithSteps rewrites the segments into an equivalent shorter form and reports the
+// rewrites. In each OR group, duplicates are removed and equalities on the same dimension are
+// merged into in-lists of at most StandardLimits.MaxInListValues items. In each AND group,
+// duplicate and tautological OR groups (e.g. `ga:pagePath=~.*`) are removed, and lower and
+// upper bounds on the same metric are merged into a range. As the bounds of `<>` on a metric
+// are exclusive, `>5;<10` becomes `<>5_10`; `>=` and `<=` are merged only on integer metrics
+// (`>=6;<=9` becomes `<>5_10`). The order of the remaining
+// expressions is kept. The input segments are not modified.
+func OptimizeWithSteps(scs Segments) (Segments, []OptimizationStep) {
+	o := &optimizer{}
+	result := make(Segments, len(scs))
+	for i, sg := range scs {
+		path := fmt.Sprintf("segments[%d]", i)
+		switch sg.Type {
+		case ConditionSegment:
+			sg.Condition.AndExpression = o.andExpression(path+".condition", sg.Condition.AndExpression)
+		case SequenceSegment:
+			steps := make(SequenceSteps, len(sg.Sequence.SequenceSteps))
+			for j, step := range sg.Sequence.SequenceSteps {
+				step.AndExpression = o.andExpression(fmt.Sprintf("%s.sequence.steps[%d]", path, j), step.AndExpression)
+				steps[j] = step
+			}
+			sg.Sequence.SequenceSteps = steps
+		}
+		result[i] = sg
+	}
+	return result, o.steps
+}
+
+type optimizer struct {
+	steps []OptimizationStep
+}
+
+func (o *optimizer) report(path, rule, before, after string) {
+	o.steps = append(o.steps, OptimizationStep{Path: path, Rule: rule, Before: before, After: after})
+}
+
+func (o *optimizer) andExpression(path string, ae AndExpression) AndExpression {
+	result := make(AndExpression, 0, len(ae))
+	seen := map[string]bool{}
+	for i, or := range ae {
+		or = o.orExpression(fmt.Sprintf("%s.and[%d]", path, i), or)
+		key := or.DefString()
+		if seen[key] {
+			o.report(fmt.Sprintf("%s.and[%d]", path, i), RuleDuplicate, or.DefString(), "")
+			continue
+		}
+		seen[key] = true
+		result = append(result, or)
+	}
+
+	// keep at least one group, as a step or a condition needs one
+	if kept := result.withoutTautologies(); len(kept) > 0 && len(kept) < len(result) {
+		o.report(path, RuleTautology, result.DefString(), kept.DefString())
+		result = kept
+	}
+
+	if merged, ok := result.mergeRanges(); ok {
+		o.report(path, RuleBetween, result.DefString(), merged.DefString())
+		result = merged
+	}
+	return result
+}
+
+func (o *optimizer) orExpression(path string, or OrExpression) OrExpression {
+	unique := make(OrExpression, 0, len(or))
+	seen := map[string]bool{}
+	for _, e := range or {
+		if key := e.DefString(); !seen[key] {
+			seen[key] = true
+			unique = append(unique, e)
+		}
+	}
+	if len(unique) < len(or) {
+		o.report(path, RuleDuplicate, or.DefString(), unique.DefString())
+	}
+	if merged, ok := unique.mergeInLists(); ok {
+		o.report(path, RuleInList, unique.DefString(), merged.DefString())
+		unique = merged
+	}
+	return unique
+}
+
+// mergeInLists merges equalities and in-lists on the same dimension.
+func (or OrExpression) mergeInLists() (OrExpression, bool) {
+	items := map[DimensionOrMetric][]string{}
+	counts := map[DimensionOrMetric]int{}
+	for _, e := range or {
+		if vs, ok := inListItems(e); ok {
+			items[e.Target] = append(items[e.Target], vs...)
+			counts[e.Target]++
+		}
+	}
+
+	result := make(OrExpression, 0, len(or))
+	changed := false
+	for _, e := range or {
+		if _, ok := inListItems(e); !ok || counts[e.Target] < 2 {
+			result = append(result, e)
+			continue
+		}
+		vs, ok := items[e.Target]
+		if !ok {
+			// merged at the first occurrence
+			continue
+		}
+		delete(items, e.Target)
+		vs = uniqueStrings(vs)
+		max := StandardLimits.MaxInListValues
+		var merged OrExpression
+		for len(vs) > 0 {
+			n := len(vs)
+			if n > max {
+				n = max
+			}
+			if n == 1 {
+				merged = append(merged, Expression{Target: e.Target, Operator: Equal, Value: vs[0]})
+			} else {
+				merged = append(merged, NewInList(e.Target, vs[:n]...))
+			}
+			vs = vs[n:]
+		}
+		if len(merged) < counts[e.Target] {
+			changed = true
+			result = append(result, merged...)
+		} else {
+			// nothing to gain
+			for _, orig := range or {
+				if orig.Target == e.Target {
+					if _, ok := inListItems(orig); ok {
+						result = append(result, orig)
+					}
+				}
+			}
+		}
+	}
+	return result, changed
+}
+
+// inListItems returns the values matched by an equality or an in-list on a dimension.
+func inListItems(e Expression) ([]string, bool) {
+	if e.MetricScope != Default || !isDimension(e.Target) {
+		return nil, false
+	}
+	switch e.Operator {
+	case Equal:
+		if e.Value == "" {
+			return nil, false
+		}
+		return []string{e.Value}, true
+	case InList:
+		vs, err := e.List()
+		return vs, err == nil
+	}
+	return nil, false
+}
+
+func isDimension(target DimensionOrMetric) bool {
+	if target == DateOfSession {
+		return false
+	}
+	ca, err := GetDimensionOrMetricAttributes(target.String())
+	return err == nil && ca.Type == "DIMENSION"
+}
+
+// withoutTautologies returns the groups which are not always true.
+func (a AndExpression) withoutTautologies() AndExpression {
+	result := make(AndExpression, 0, len(a))
+	for _, or := range a {
+		if !or.isTautology() {
+			result = append(result, or)
+		}
+	}
+	return result
+}
+
+var complementOperators = map[Operator]Operator{
+	Equal:             NotEqual,
+	ContainsSubstring: NotContainsSubstring,
+	Regexp:            NotRegexp,
+	InList:            NotInList,
+	Between:           NotBetween,
+}
+
+func (or OrExpression) isTautology() bool {
+	seen := map[Expression]bool{}
+	for _, e := range or {
+		e.Source = nil
+		switch {
+		case e.Operator == ContainsSubstring && e.Value == "":
+			return true
+		case e.Operator == Regexp && (e.Value == "" || e.Value == ".*" || e.Value == "^" || e.Value == "^.*"):
+			return true
+		}
+		for op, cop := range complementOperators {
+			c := e
+			switch e.Operator {
+			case op:
+				c.Operator = cop
+			case cop:
+				c.Operator = op
+			default:
+				continue
+			}
+			if seen[c] {
+				return true
+			}
+		}
+		seen[e] = true
+	}
+	return false
+}
+
+type bound struct {
+	index int
+	value float64
+}
+
+// mergeRanges merges a lower and an upper bound on the same metric into a range.
+func (a AndExpression) mergeRanges() (AndExpression, bool) {
+	type key struct {
+		scope  MetricScope
+		target DimensionOrMetric
+	}
+	lowers := map[key]bound{}
+	uppers := map[key]bound{}
+	for i, or := range a {
+		if len(or) != 1 {
+			continue
+		}
+		e := or[0]
+		v, ok := exclusiveBound(e)
+		if !ok {
+			continue
+		}
+		k := key{e.MetricScope, e.Target}
+		switch e.Operator {
+		case GreaterThan, GreaterThanEqual:
+			if _, ok := lowers[k]; !ok {
+				lowers[k] = bound{i, v}
+			}
+		case LessThan, LessThanEqual:
+			if _, ok := uppers[k]; !ok {
+				uppers[k] = bound{i, v}
+			}
+		}
+	}
+
+	replace := map[int]Expression{}
+	drop := map[int]bool{}
+	for k, lower := range lowers {
+		upper, ok := uppers[k]
+		if !ok || lower.value >= upper.value {
+			continue
+		}
+		e := NewNumberBetween(k.target, lower.value, upper.value)
+		e.MetricScope = k.scope
+		first, second := lower.index, upper.index
+		if second < first {
+			first, second = second, first
+		}
+		replace[first] = e
+		drop[second] = true
+	}
+	if len(replace) == 0 {
+		return a, false
+	}
+
+	result := make(AndExpression, 0, len(a))
+	for i, or := range a {
+		if drop[i] {
+			continue
+		}
+		if e, ok := replace[i]; ok {
+			or = OrExpression{e}
+		}
+		result = append(result, or)
+	}
+	return result, true
+}
+
+// exclusiveBound returns the value of a comparison on a metric as an exclusive bound.
+// Inclusive bounds are converted only for integer metrics.
+func exclusiveBound(e Expression) (float64, bool) {
+	if e.Target == DateOfSession {
+		return 0, false
+	}
+	ca, err := GetDimensionOrMetricAttributes(e.Target.String())
+	if err != nil || ca.Type != "METRIC" {
+		return 0, false
+	}
+	v, err := e.Number()
+	if err != nil {
+		return 0, false
+	}
+	switch e.Operator {
+	case GreaterThan, LessThan:
+		return v, true
+	case GreaterThanEqual, LessThanEqual:
+		if ca.DataType != "INTEGER" || v != math.Trunc(v) {
+			return 0, false
+		}
+		if e.Operator == GreaterThanEqual {
+			return v - 1, true
+		}
+		return v + 1, true
+	}
+	return 0, false
+}
+
+func uniqueStrings(ss []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
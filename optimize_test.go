@@ -0,0 +1,107 @@
+package gasegment
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptimize(t *testing.T) {
+	table := []struct {
+		definition string
+		expected   string
+	}{
+		{
+			"users::condition::ga:pagePath==/a,ga:pagePath==/b,ga:hits>1,ga:pagePath==/c",
+			"users::condition::ga:pagePath[]/a|/b|/c,ga:hits>1",
+		},
+		{
+			`users::condition::ga:pagePath[]/a|/b,ga:pagePath==/c|d,ga:pagePath==/a`,
+			`users::condition::ga:pagePath[]/a|/b|/c\|d`,
+		},
+		{
+			// 12 values are split at 10
+			"users::condition::ga:pagePath==/1,ga:pagePath==/2,ga:pagePath==/3,ga:pagePath==/4,ga:pagePath==/5,ga:pagePath==/6,ga:pagePath==/7,ga:pagePath==/8,ga:pagePath==/9,ga:pagePath==/10,ga:pagePath==/11,ga:pagePath==/12",
+			"users::condition::ga:pagePath[]/1|/2|/3|/4|/5|/6|/7|/8|/9|/10,ga:pagePath[]/11|/12",
+		},
+		{
+			// metrics are not merged into in-lists
+			"users::condition::ga:hits==1,ga:hits==2",
+			"users::condition::ga:hits==1,ga:hits==2",
+		},
+		{
+			// the bounds of <> on a metric are exclusive
+			"users::condition::ga:sessions>5;ga:pagePath==/a;ga:sessions<10",
+			"users::condition::ga:sessions<>5_10;ga:pagePath==/a",
+		},
+		{
+			"users::condition::ga:transactionRevenue>5;ga:transactionRevenue<10",
+			"users::condition::ga:transactionRevenue<>5_10",
+		},
+		{
+			"users::condition::ga:sessions<=9;ga:sessions>=6",
+			"users::condition::ga:sessions<>5_10",
+		},
+		{
+			// inclusive bounds of a non integer metric can't be made exclusive
+			"users::condition::perSession::ga:sessionDuration<=60;perSession::ga:sessionDuration>=10",
+			"users::condition::perSession::ga:sessionDuration<=60;perSession::ga:sessionDuration>=10",
+		},
+		{
+			"users::condition::ga:sessions>=1.5;ga:sessions<=9",
+			"users::condition::ga:sessions>=1.5;ga:sessions<=9",
+		},
+		{
+			// contradiction is kept as is
+			"users::condition::ga:sessions>=10;ga:sessions<=5",
+			"users::condition::ga:sessions>=10;ga:sessions<=5",
+		},
+		{
+			"users::condition::ga:sessions>5;ga:sessions<5",
+			"users::condition::ga:sessions>5;ga:sessions<5",
+		},
+		{
+			"users::condition::ga:pagePath==/a,ga:pagePath==/a;ga:pagePath==/a;ga:hits>1,ga:hits>1",
+			"users::condition::ga:pagePath==/a;ga:hits>1",
+		},
+		{
+			"users::condition::ga:pagePath=~.*;ga:hits>1;ga:medium==cpc,ga:medium!=cpc",
+			"users::condition::ga:hits>1",
+		},
+		{
+			// at least one group is kept
+			"users::condition::ga:pagePath=~.*",
+			"users::condition::ga:pagePath=~.*",
+		},
+		{
+			"users::sequence::ga:pagePath==/a,ga:pagePath==/b;->>ga:sessions>=1;ga:sessions<=2",
+			"users::sequence::ga:pagePath[]/a|/b;->>ga:sessions<>0_3",
+		},
+	}
+	for _, c := range table {
+		ss := MustParse(c.definition)
+		act := Optimize(ss)
+		if def := act.DefString(); def != c.expected {
+			t.Errorf("%s\n\texpected: %s\n\tactual:   %s", c.definition, c.expected, def)
+		}
+		if def := ss.DefString(); def != MustParse(c.definition).DefString() {
+			t.Errorf("%s: input is modified to %s", c.definition, def)
+		}
+	}
+}
+
+func TestOptimizeWithSteps(t *testing.T) {
+	_, steps := OptimizeWithSteps(MustParse("users::condition::ga:hits>1;ga:pagePath==/a,ga:pagePath==/b,ga:pagePath==/a;ga:hits>1"))
+	var act []string
+	for _, s := range steps {
+		act = append(act, s.String())
+	}
+	expected := []string{
+		"segments[0].condition.and[1]: remove duplicate: ga:pagePath==/a,ga:pagePath==/b,ga:pagePath==/a => ga:pagePath==/a,ga:pagePath==/b",
+		"segments[0].condition.and[1]: merge equalities into in-list: ga:pagePath==/a,ga:pagePath==/b => ga:pagePath[]/a|/b",
+		"segments[0].condition.and[2]: remove duplicate: ga:hits>1 => ",
+	}
+	if !reflect.DeepEqual(act, expected) {
+		t.Errorf("unexpected steps\n\texpected: %s\n\tactual:   %s", strings.Join(expected, "\n\t\t"), strings.Join(act, "\n\t\t"))
+	}
+}
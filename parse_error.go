@@ -0,0 +1,59 @@
+package gasegment
+
+// Expected tokens reported by ParseError.
+const (
+	ExpectedScope       = "scope"
+	ExpectedSegmentType = "segment type"
+	ExpectedTarget      = "dimension or metric"
+	ExpectedOperator    = "operator"
+	ExpectedValue       = "value"
+)
+
+// ParseError describes where and why parsing a segment definition failed.
+//
+// Offset and End are byte offsets into the definition; Fragment is the failing span.
+// The index fields are -1 when the error is not located inside such a node.
+type ParseError struct {
+	Offset   int
+	End      int
+	Fragment string
+
+	SegmentIndex int
+	StepIndex    int
+	AndIndex     int
+	OrIndex      int
+
+	Expected []string
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+// location tracks the node indexes while descending into a definition.
+type location struct {
+	segment, step, and, or int
+}
+
+var noLocation = location{-1, -1, -1, -1}
+
+func newParseError(definition string, offset, end int, loc location, message string, expected ...string) *ParseError {
+	if end > len(definition) {
+		end = len(definition)
+	}
+	if offset > end {
+		offset = end
+	}
+	return &ParseError{
+		Offset:       offset,
+		End:          end,
+		Fragment:     definition[offset:end],
+		SegmentIndex: loc.segment,
+		StepIndex:    loc.step,
+		AndIndex:     loc.and,
+		OrIndex:      loc.or,
+		Expected:     expected,
+		Message:      message,
+	}
+}
@@ -0,0 +1,76 @@
+package gasegment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	table := []struct {
+		definition string
+		message    string
+		fragment   string
+		offset     int
+		loc        location
+		expected   []string
+	}{
+		{
+			definition: "ga:pagePath==/abc",
+			message:    "unknown segment condition ga:pagePath==/abc",
+			fragment:   "ga:pagePath==/abc",
+			offset:     0,
+			loc:        location{0, -1, -1, -1},
+			expected:   []string{ExpectedScope, ExpectedSegmentType},
+		},
+		{
+			definition: "condition::ga:pagePath==/abc",
+			message:    "no segment scope (user:: or session::)",
+			fragment:   "condition::ga:pagePath==/abc",
+			offset:     0,
+			loc:        location{0, -1, -1, -1},
+			expected:   []string{ExpectedScope},
+		},
+		{
+			definition: "users::condition::ga:pagePath==/abc;ga:sessions>1,ga:hits",
+			message:    "invalid expression: ga:hits",
+			fragment:   "ga:hits",
+			offset:     50,
+			loc:        location{0, -1, 1, 1},
+			expected:   []string{ExpectedOperator},
+		},
+		{
+			definition: "users::condition::ga:pagePath==/abc;sessions::sequence::ga:hits>1;->>perHit::==3",
+			message:    "empty dimension or metric",
+			fragment:   "==",
+			offset:     77,
+			loc:        location{1, 1, 0, 0},
+			expected:   []string{ExpectedTarget},
+		},
+	}
+
+	for _, c := range table {
+		_, err := Parse(c.definition)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: expected *ParseError, got %v", c.definition, err)
+			continue
+		}
+		if pe.Error() != c.message {
+			t.Errorf("%s: unexpected message %q", c.definition, pe.Error())
+		}
+		if pe.Offset != c.offset || pe.Fragment != c.fragment {
+			t.Errorf("%s: unexpected span %d %q", c.definition, pe.Offset, pe.Fragment)
+		}
+		if c.definition[pe.Offset:pe.End] != pe.Fragment {
+			t.Errorf("%s: fragment does not match span", c.definition)
+		}
+		loc := location{pe.SegmentIndex, pe.StepIndex, pe.AndIndex, pe.OrIndex}
+		if loc != c.loc {
+			t.Errorf("%s: unexpected location %v", c.definition, loc)
+		}
+		if !reflect.DeepEqual(pe.Expected, c.expected) {
+			t.Errorf("%s: unexpected expected tokens %v", c.definition, pe.Expected)
+		}
+	}
+}
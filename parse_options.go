@@ -0,0 +1,73 @@
+package gasegment
+
+// ParseOptions controls the dialect accepted by the parser.
+// The zero value accepts the same definitions as Parse.
+type ParseOptions struct {
+	// DefaultScope is given to a segment without scope when no scope can be inherited.
+	DefaultScope SegmentScope
+	// NoScopeInheritance stops a segment without scope from inheriting the scope of the previous segment.
+	NoScopeInheritance bool
+	// RejectUnknownTargets makes dimensions and metrics missing from the metadata an error.
+	RejectUnknownTargets bool
+	// ValidateMetadata checks each expression with ValidateExpression while parsing.
+	// Unknown dimensions and metrics are still accepted unless RejectUnknownTargets is set.
+	ValidateMetadata bool
+	// AllowWhitespace ignores whitespace around separators (`;`, `,`, `;->`, `;->>`)
+	// and at both ends of the definition. Leading and trailing spaces of values are lost.
+	AllowWhitespace bool
+	// RecordSource records the source position of each Segment, SequenceStep and Expression (see Patch).
+	RecordSource bool
+}
+
+var (
+	// StrictParseOptions is meant for definitions about to be published.
+	StrictParseOptions = ParseOptions{
+		RejectUnknownTargets: true,
+		ValidateMetadata:     true,
+	}
+	// LenientParseOptions is meant for importing hand written or legacy definitions.
+	LenientParseOptions = ParseOptions{
+		DefaultScope:    SessionScope,
+		AllowWhitespace: true,
+	}
+)
+
+func ParseWithOptions(definition string, opts ParseOptions) (Segments, error) {
+	return opts.Parse(definition)
+}
+
+func (opts ParseOptions) Parse(definition string) (Segments, error) {
+	p := newParserWithOptions(definition, opts)
+	return p.parseSegments()
+}
+
+func (opts ParseOptions) ParseWithDiagnostics(definition string) (Segments, Diagnostics) {
+	p := newParserWithOptions(definition, opts)
+	p.recovering = true
+	segments, err := p.parseSegments()
+	if err != nil {
+		// not recoverable; report it as is
+		if pe, ok := err.(*ParseError); ok {
+			p.report(SeverityError, pe)
+		}
+	}
+	return segments, p.diagnostics
+}
+
+// checkMetadata returns the metadata error of the expression which is fatal under the options.
+func (opts ParseOptions) checkMetadata(e Expression) error {
+	if !opts.ValidateMetadata && !opts.RejectUnknownTargets {
+		return nil
+	}
+	err := ValidateExpression(e)
+	if err == NoSuchDimensionOrMetric {
+		if opts.RejectUnknownTargets {
+			return DimensionOrMetricError("no such dimension or metric: " + e.Target.String())
+		}
+		return nil
+	}
+	if opts.ValidateMetadata {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package gasegment
+
+import "testing"
+
+func TestParseWithOptions(t *testing.T) {
+	table := []struct {
+		definition string
+		opts       ParseOptions
+		expected   string // "" means error
+	}{
+		// scope
+		{"condition::ga:pagePath==/a", ParseOptions{}, ""},
+		{"condition::ga:pagePath==/a", ParseOptions{DefaultScope: UserScope}, "users::condition::ga:pagePath==/a"},
+		{"sessions::condition::ga:pagePath==/a;condition::ga:pagePath==/b", ParseOptions{}, "sessions::condition::ga:pagePath==/a;condition::ga:pagePath==/b"},
+		{"sessions::condition::ga:pagePath==/a;condition::ga:pagePath==/b", ParseOptions{NoScopeInheritance: true}, ""},
+		{"sessions::condition::ga:pagePath==/a;condition::ga:pagePath==/b", ParseOptions{NoScopeInheritance: true, DefaultScope: UserScope}, "users::condition::ga:pagePath==/b;sessions::condition::ga:pagePath==/a"},
+
+		// metadata
+		{"users::condition::ga:noSuchDimension==a", ParseOptions{}, "users::condition::ga:noSuchDimension==a"},
+		{"users::condition::ga:noSuchDimension==a", ParseOptions{ValidateMetadata: true}, "users::condition::ga:noSuchDimension==a"},
+		{"users::condition::ga:noSuchDimension==a", ParseOptions{RejectUnknownTargets: true}, ""},
+		{"users::condition::perProduct::ga:sessions>1", ParseOptions{}, "users::condition::perProduct::ga:sessions>1"},
+		{"users::condition::perProduct::ga:sessions>1", ParseOptions{RejectUnknownTargets: true}, "users::condition::perProduct::ga:sessions>1"},
+		{"users::condition::perProduct::ga:sessions>1", ParseOptions{ValidateMetadata: true}, ""},
+		{"users::condition::perProduct::ga:itemRevenue>1", StrictParseOptions, "users::condition::perProduct::ga:itemRevenue>1"},
+
+		// whitespace
+		{"users::condition::ga:pagePath==/a ; ga:pagePath==/b", ParseOptions{}, "users::condition::ga:pagePath==/a ; ga:pagePath==/b"},
+		{" users::condition::ga:pagePath==/a ;ga:keyword=@book off , ga:pagePath==/b ", ParseOptions{}, ""},
+		{" users::condition::ga:pagePath==/a ;ga:keyword=@book off , ga:pagePath==/b ", ParseOptions{AllowWhitespace: true}, "users::condition::ga:pagePath==/a;ga:keyword=@book off,ga:pagePath==/b"},
+		{"users::sequence:: ga:pagePath==/a ;->> ga:pagePath==/b ; sessions::condition::ga:hits>1", ParseOptions{AllowWhitespace: true}, "users::sequence::ga:pagePath==/a;->>ga:pagePath==/b;sessions::condition::ga:hits>1"},
+		{"condition::ga:pagePath==/a ;\n condition::ga:pagePath==/b", LenientParseOptions, "sessions::condition::ga:pagePath==/a;condition::ga:pagePath==/b"},
+	}
+
+	for _, c := range table {
+		ss, err := ParseWithOptions(c.definition, c.opts)
+		if c.expected == "" {
+			if err == nil {
+				t.Errorf("parse %q with %+v must be error", c.definition, c.opts)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q with %+v: %s", c.definition, c.opts, err)
+			continue
+		}
+		if act := ss.DefString(); act != c.expected {
+			t.Errorf("%q with %+v\n\texpected: %s\n\tactual:   %s", c.definition, c.opts, c.expected, act)
+		}
+	}
+}
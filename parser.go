@@ -4,10 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-
 	"strings"
 )
 
+// Deprecated: Parse no longer splits definitions with regular expressions.
+// These are unused and stay only because they are exported.
 var SegmentConditionRe,
 	SequenceSeparatorRe,
 	AndSeparatorRe,
@@ -20,24 +21,7 @@ func init() {
 	AndSeparatorRe = regexp.MustCompile(`(?:\\.|[^\\])(;)`)
 	OrSeparatorRe = regexp.MustCompile(`(?:\\.|[^\\])(,)`)
 
-	// ORDER IS IMPORTANT.
-	// e.g. using invalid ordering - such as `LessThan, LesthanEqual`, then, regexp is />|>=/, so, ">=" is matching at `>`(LessThan). NG
-	ops := []Operator{
-		Equal,
-		NotEqual,
-		LessThanEqual,
-		GreaterThanEqual,
-		NotBetween,
-		Between,
-		GreaterThan,
-		LessThan,
-		NotInList,
-		InList,
-		ContainsSubstring,
-		NotContainsSubstring,
-		Regexp,
-		NotRegexp,
-	}
+	ops := operators
 	opbuf := make([]string, len(ops))
 	for i, op := range ops {
 		opbuf[i] = regexp.QuoteMeta(op.String())
@@ -54,197 +38,478 @@ func MustParse(definition string) Segments {
 }
 
 func Parse(definition string) (Segments, error) {
-	parts := splitByFirstRegexpGroup(definition, SegmentConditionRe)
+	return ParseOptions{}.Parse(definition)
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+	loc    location
+	opts   ParseOptions
+
+	// recovering parser records errors as diagnostics and skips the broken node instead of stopping.
+	recovering  bool
+	diagnostics Diagnostics
+}
+
+// errSkipped is returned inside a recovering parser when a broken node was reported and must be dropped.
+var errSkipped = errors.New("skipped")
+
+func newParserWithOptions(definition string, opts ParseOptions) *parser {
+	return &parser{
+		src:    definition,
+		tokens: lexWithOptions(definition, opts.AllowWhitespace),
+		loc:    noLocation,
+		opts:   opts,
+	}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(kind tokenKind) (token, bool) {
+	if t := p.peek(); t.kind == kind {
+		return p.next(), true
+	}
+	return token{}, false
+}
+
+// skipTo advances to the next token of one of the kinds (or EOF).
+func (p *parser) skipTo(kinds ...tokenKind) {
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF {
+			return
+		}
+		for _, k := range kinds {
+			if t.kind == k {
+				return
+			}
+		}
+		p.next()
+	}
+}
+
+func (p *parser) errorAt(t token, message string, expected ...string) *ParseError {
+	if t.kind == tokenIllegal {
+		return newParseError(p.src, t.pos, t.end, p.loc, t.message, t.expected...)
+	}
+	return newParseError(p.src, t.pos, t.end, p.loc, message, expected...)
+}
+
+// fail returns err, or records it and returns errSkipped when recovering.
+func (p *parser) fail(err *ParseError) error {
+	if !p.recovering {
+		return err
+	}
+	p.report(SeverityError, err)
+	return errSkipped
+}
+
+func (p *parser) report(severity Severity, err *ParseError) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{Severity: severity, ParseError: *err})
+}
+
+func (p *parser) parseSegments() (Segments, error) {
 	ret := []Segment{}
 	var lastScope SegmentScope
-	for i := 0; i < len(parts); i += 2 {
-		sd := parts[i]
-		s, err := parseSegment(sd)
-		if err != nil {
+	ref := -1
+	var refToken token
+	for i := 0; ; i++ {
+		p.loc = noLocation
+		p.loc.segment = i
+		start := p.peek()
+		s, err := p.parseSegment()
+		if err == errSkipped {
+			p.skipTo(tokenSegmentSeparator)
+			// a broken segment still passes its scope on to the following ones
+			if start.kind == tokenScope {
+				lastScope = SegmentScope(start.text)
+			}
+		} else if err != nil {
 			return nil, err
-		}
-		if s.Scope.String() == "" {
-			if lastScope.String() == "" {
-				return Segments{}, errors.New("no segment scope (user:: or session::)")
+		} else if s.Type == ReferenceSegment {
+			if ref < 0 {
+				ref = len(ret)
+				refToken = token{pos: start.pos, end: p.tokens[p.pos-1].end}
+			}
+			ret = append(ret, s)
+		} else if s.Scope.String() == "" && (lastScope.String() == "" || p.opts.NoScopeInheritance) && p.opts.DefaultScope.String() == "" {
+			end := p.tokens[p.pos-1]
+			if err := p.fail(newParseError(p.src, start.pos, end.end, p.loc, "no segment scope (user:: or session::)", ExpectedScope)); err != errSkipped {
+				return Segments{}, err
 			}
-			s.Scope = lastScope
+		} else {
+			if s.Scope.String() == "" {
+				if lastScope.String() != "" && !p.opts.NoScopeInheritance {
+					s.Scope = lastScope
+				} else {
+					s.Scope = p.opts.DefaultScope
+				}
+			}
+			ret = append(ret, s)
+			lastScope = s.Scope
+		}
+
+		if _, ok := p.accept(tokenSegmentSeparator); !ok {
+			break
 		}
-		ret = append(ret, s)
-		lastScope = s.Scope
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorAt(t, fmt.Sprintf("unexpected %q", t.text))
+	}
+	if ref >= 0 && len(ret) > 1 {
+		p.loc = noLocation
+		p.loc.segment = ref
+		if err := p.fail(p.errorAt(refToken, "gaid:: segment cannot be combined with other segments")); err != errSkipped {
+			return nil, err
+		}
+		ret = append(ret[:ref], ret[ref+1:]...)
 	}
 	return Segments(ret), nil
 }
 
-func parseSegment(definition string) (Segment, error) {
-	s := definition
+func (p *parser) parseSegment() (Segment, error) {
 	sg := Segment{}
+	start := p.peek()
+	diagnostics := len(p.diagnostics)
 
-	// parse scope
-	if strings.HasPrefix(s, "sessions::") {
-		sg.Scope = SessionScope
-		s = s[len("sessions::"):]
-	}
-	if strings.HasPrefix(s, "users::") {
-		sg.Scope = UserScope
-		s = s[len("users::"):]
+	if t, ok := p.accept(tokenScope); ok {
+		sg.Scope = SegmentScope(t.text)
 	}
 
-	// condition
-	if strings.HasPrefix(s, "condition::") {
-		sg.Type = ConditionSegment
-		s = s[len("condition::"):]
-
-		c, err := parseCondition(s)
+	t := p.next()
+	if t.kind != tokenSegmentType {
+		return sg, p.fail(p.errorAt(t, fmt.Sprintf("unknown segment condition %s", t.text), ExpectedSegmentType))
+	}
+	sg.Type = SegmentType(t.text)
+	body := t
+	switch sg.Type {
+	case ConditionSegment:
+		c, err := p.parseCondition()
 		if err != nil {
 			return Segment{}, err
 		}
 		sg.Condition = c
-	} else if strings.HasPrefix(s, "sequence::") {
-		sg.Type = SequenceSegment
-		s = s[len("sequence::"):]
-		sq, err := parseSequence(s)
+		if len(c.AndExpression) == 0 && c.DateRange == nil {
+			return Segment{}, p.dropped(start)
+		}
+	case SequenceSegment:
+		sq, err := p.parseSequence()
 		if err != nil {
 			return Segment{}, err
 		}
 		sg.Sequence = sq
-	} else {
-		return sg, errors.New(fmt.Sprintf("unknown segment condition %s", s))
+		if len(sq.SequenceSteps) == 0 {
+			return Segment{}, p.dropped(start)
+		}
+	case ReferenceSegment:
+		t := p.next()
+		if sg.Scope != "" {
+			return Segment{}, p.fail(p.errorAt(token{pos: start.pos, end: t.end}, "gaid:: segment cannot have a scope"))
+		}
+		if t.text == "" {
+			return Segment{}, p.fail(p.errorAt(t, "empty segment id"))
+		}
+		sg.Reference = SegmentRef{ID: t.text}
+	}
+	if p.opts.RecordSource && len(p.diagnostics) == diagnostics {
+		sg.Source = p.source(start, body, sg.DefStringWithoutScope())
 	}
-
 	return sg, nil
 }
 
-func parseSequence(definition string) (Sequence, error) {
-	s := definition
+// source records the span from the start token to the last consumed token.
+func (p *parser) source(start, body token, def string) *Source {
+	end := p.tokens[p.pos-1].end
+	return &Source{
+		Span: Span{Start: start.pos, End: end},
+		Body: Span{Start: body.pos, End: end},
+		def:  def,
+	}
+}
+
+// dropped reports a segment left empty after its broken expressions were skipped.
+func (p *parser) dropped(start token) error {
+	end := p.tokens[p.pos-1]
+	p.report(SeverityWarning, newParseError(p.src, start.pos, end.end, p.loc, "segment dropped: no valid expression"))
+	return errSkipped
+}
+
+func (p *parser) parseSequence() (Sequence, error) {
 	seq := Sequence{}
-	// not?
-	if strings.HasPrefix(s, "!") {
+	if _, ok := p.accept(tokenNot); ok {
 		seq.Not = true
-		s = s[len("!"):]
 	}
-
-	// FirstHitMatchesFirstStep?
-	if strings.HasPrefix(s, "^") {
+	if _, ok := p.accept(tokenFirstHit); ok {
 		seq.FirstHitMatchesFirstStep = true
-		s = s[len("^"):]
 	}
 
-	// Sequence
 	steps := []SequenceStep{}
-	sParts := splitByFirstRegexpGroup(s, SequenceSeparatorRe)
-
-	first, err := parseAndExpression(sParts[0])
-	if err != nil {
-		return Sequence{}, err
-	}
-	steps = append(steps, SequenceStep{
-		Type:          FirstStep,
-		AndExpression: first,
-	})
-	for i := 1; i < len(sParts); i += 2 {
-		step := SequenceStep{}
-		typeStr := sParts[i]
-		ae, err := parseAndExpression(sParts[i+1])
+	stepType := FirstStep
+	start := p.peek()
+	for i := 0; ; i++ {
+		p.loc.step = i
+		diagnostics := len(p.diagnostics)
+		body := p.peek()
+		ae, err := p.parseAndExpression()
 		if err != nil {
 			return Sequence{}, err
 		}
-		step.Type = SequenceStepType(typeStr)
-		step.AndExpression = ae
-		steps = append(steps, step)
+		if len(ae) > 0 {
+			if len(steps) == 0 {
+				stepType = FirstStep
+			}
+			step := SequenceStep{Type: stepType, AndExpression: ae}
+			if len(steps) == 0 {
+				if step.DateRange, step.AndExpression, err = splitDateRange(ae); err != nil {
+					return Sequence{}, err
+				}
+			}
+			if p.opts.RecordSource && len(p.diagnostics) == diagnostics && (i == 0) == (stepType == FirstStep) {
+				step.Source = p.source(start, body, step.DefString())
+			}
+			steps = append(steps, step)
+		}
+		t, ok := p.accept(tokenStepSeparator)
+		if !ok {
+			break
+		}
+		stepType = SequenceStepType(t.text)
+		start = t
 	}
 	seq.SequenceSteps = SequenceSteps(steps)
 	return seq, nil
 }
 
-func parseCondition(definition string) (Condition, error) {
-	s := definition
+func (p *parser) parseCondition() (Condition, error) {
 	c := Condition{}
-	if strings.HasPrefix(s, "!") {
+	if _, ok := p.accept(tokenNot); ok {
 		c.Exclude = true
-		s = s[len("!"):]
 	}
 
-	ae, err := parseAndExpression(s)
+	ae, err := p.parseAndExpression()
 	if err != nil {
 		return Condition{}, err
 	}
-
-	c.AndExpression = ae
+	if t := p.peek(); t.kind == tokenStepSeparator {
+		if err := p.fail(p.errorAt(t, fmt.Sprintf("sequence step %s in condition segment", t.text))); err != errSkipped {
+			return Condition{}, err
+		}
+		p.skipTo(tokenSegmentSeparator)
+	}
+	// checkDateOfSession allows dateOfSession only where splitDateRange takes it
+	if c.DateRange, c.AndExpression, err = splitDateRange(ae); err != nil {
+		return Condition{}, err
+	}
 
 	return c, nil
 }
 
-func parseAndExpression(definition string) (AndExpression, error) {
-	parts := splitByFirstRegexpGroup(definition, AndSeparatorRe)
+func (p *parser) parseAndExpression() (AndExpression, error) {
 	orExpressions := []OrExpression{}
-	for i := 0; i < len(parts); i += 2 {
-		or, err := parseOrExpression(parts[i])
+	for i := 0; ; i++ {
+		p.loc.and = i
+		or, err := p.parseOrExpression()
 		if err != nil {
 			return AndExpression{}, err
 		}
-		orExpressions = append(orExpressions, or)
+		if len(or) > 0 {
+			orExpressions = append(orExpressions, or)
+		}
+		if _, ok := p.accept(tokenAndSeparator); !ok {
+			break
+		}
 	}
-
+	p.loc.and = -1
 	return AndExpression(orExpressions), nil
 }
 
-func parseOrExpression(definition string) (OrExpression, error) {
-	parts := splitByFirstRegexpGroup(definition, OrSeparatorRe)
+func (p *parser) parseOrExpression() (OrExpression, error) {
 	expressions := []Expression{}
-	for i := 0; i < len(parts); i += 2 {
-		or, err := parseExpression(parts[i])
-		if err != nil {
+	for i := 0; ; i++ {
+		p.loc.or = i
+		e, err := p.parseExpression()
+		if err == errSkipped {
+			p.skipTo(tokenOrSeparator, tokenAndSeparator, tokenStepSeparator, tokenSegmentSeparator)
+		} else if err != nil {
 			return OrExpression{}, err
+		} else {
+			expressions = append(expressions, e)
+		}
+		t, ok := p.accept(tokenOrSeparator)
+		if !ok {
+			break
+		}
+		if err == nil && e.IsDateOfSession() {
+			if err := p.fail(p.errorAt(t, "dateOfSession cannot be combined with other expressions by OR")); err != errSkipped {
+				return OrExpression{}, err
+			}
+			expressions = expressions[:len(expressions)-1]
 		}
-		expressions = append(expressions, or)
 	}
-
+	p.loc.or = -1
 	return OrExpression(expressions), nil
 }
 
-func parseExpression(definition string) (Expression, error) {
+func (p *parser) parseExpression() (Expression, error) {
 	e := Expression{}
-	s := definition
-	mss := []MetricScope{PerHit, PerUser, PerSession}
-	for _, ms := range mss {
-		if strings.HasPrefix(s, ms.String()) {
-			e.MetricScope = ms
-			s = s[len(ms.String()):]
-			break
-		}
+	start := p.peek()
+	if t, ok := p.accept(tokenMetricScope); ok {
+		e.MetricScope = MetricScope(t.text)
 	}
 
-	idxes := OpSeparatorRe.FindAllStringIndex(s, -1)
-	if len(idxes) == 0 {
-		return Expression{}, errors.New(fmt.Sprintf("invalid expression: %s", definition))
+	t := p.next()
+	if t.kind != tokenTarget {
+		return Expression{}, p.fail(p.errorAt(t, fmt.Sprintf("invalid expression: %s", t.text), ExpectedTarget))
 	}
-	opi := idxes[0]
+	e.Target = DimensionOrMetric(t.text)
 
-	e.Target = DimensionOrMetric(s[:opi[0]])
-	if e.Target == "" {
-		return Expression{}, errors.New("empty dimension or metric")
+	t = p.next()
+	if t.kind != tokenOperator {
+		return Expression{}, p.fail(p.errorAt(t, fmt.Sprintf("invalid expression: %s", t.text), ExpectedOperator))
+	}
+	e.Operator = Operator(t.text)
+
+	t = p.next()
+	if t.kind != tokenValue {
+		return Expression{}, p.fail(p.errorAt(t, fmt.Sprintf("invalid expression: %s", t.text), ExpectedValue))
+	}
+	e.Value = UnescapeValue(e.Operator, t.text)
+
+	value := t
+	span := token{pos: start.pos, end: t.end}
+	if e.IsDateOfSession() {
+		if err := p.checkDateOfSession(e, span); err != nil {
+			return Expression{}, p.fail(err)
+		}
+	}
+	if err := p.opts.checkMetadata(e); err != nil {
+		return Expression{}, p.fail(p.errorAt(span, err.Error()))
+	}
+	if p.recovering {
+		if err := ValidateExpression(e); err != nil {
+			p.report(SeverityWarning, p.errorAt(span, err.Error()))
+		}
+	}
+	if p.opts.RecordSource {
+		e.Source = p.source(start, value, e.DefString())
 	}
-	e.Operator = Operator(s[opi[0]:opi[1]])
-	e.Value = UnEscapeExpressionValue(s[opi[1]:])
 	return e, nil
 }
 
-func splitByFirstRegexpGroup(s string, r *regexp.Regexp) []string {
-	indexes := r.FindAllStringSubmatchIndex(s, -1)
-	if len(indexes) == 0 {
-		return []string{s}
+// checkDateOfSession checks the placement of a dateOfSession expression: alone
+// in the first AND group of a condition or of the first step of a sequence.
+func (p *parser) checkDateOfSession(e Expression, span token) *ParseError {
+	switch {
+	case p.loc.step > 0:
+		return p.errorAt(span, "dateOfSession is only allowed in the first step of a sequence")
+	case p.loc.and > 0:
+		return p.errorAt(span, "dateOfSession is only allowed as the first condition of a segment")
+	case p.loc.or > 0:
+		return p.errorAt(span, "dateOfSession cannot be combined with other expressions by OR")
 	}
-	ret := make([]string, len(indexes)*2+1)
-	last := []int{0, 0}
-	for i, idx := range indexes {
-		if len(idx) < 4 {
-			panic("regexp must contains group")
+	if _, err := e.DateOfSession(); err != nil {
+		return p.errorAt(span, err.Error())
+	}
+	return nil
+}
+
+// checkStructure runs the checks of the parser which don't depend on the syntax
+// on node, for segments which are not parsed, e.g. built by a Builder or decoded
+// from JSON: a segment needs a scope unless an earlier one has it, gaid:: stands
+// alone, and so on. The error has the path of the offending node.
+func checkStructure(node Node) error {
+	var err error
+	fail := func(path Path, message string) bool {
+		err = fmt.Errorf("%s: %s", path, message)
+		return false
+	}
+	Inspect(node, func(n Node, path Path) bool {
+		if err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case Segments:
+			var scope SegmentScope
+			for i, sg := range n {
+				sgPath := append(path, PathElem{Node: sg, Index: i})
+				switch {
+				case sg.Type == ReferenceSegment:
+					if len(n) > 1 {
+						return fail(sgPath, "gaid:: segment cannot be combined with other segments")
+					}
+				case sg.Scope == "" && scope == "":
+					return fail(sgPath, "no segment scope (user:: or session::)")
+				case sg.Scope != "":
+					scope = sg.Scope
+				}
+			}
+		case Condition:
+			return checkDateRange(path, n.DateRange, n.AndExpression, fail)
+		case Sequence:
+			if len(n.SequenceSteps) == 0 {
+				return fail(path, "no step")
+			}
+			for i, step := range n.SequenceSteps {
+				stepPath := append(path, PathElem{Node: step, Name: "steps", Index: i})
+				switch {
+				case i == 0 && step.Type != FirstStep:
+					return fail(stepPath, fmt.Sprintf("first step cannot follow another with %s", step.Type))
+				case i > 0 && step.Type == FirstStep:
+					return fail(stepPath, fmt.Sprintf("step must follow the previous one with %s or %s", Precedes, ImmediatelyPrecedes))
+				}
+			}
+		case SequenceStep:
+			if last := path[len(path)-1]; last.Index > 0 && n.DateRange != nil {
+				return fail(path, "dateOfSession is only allowed in the first step of a sequence")
+			}
+			return checkDateRange(path, n.DateRange, n.AndExpression, fail)
+		case OrExpression:
+			if len(n) == 0 {
+				return fail(path, "no expression")
+			}
+		case Expression:
+			// the parser takes a dateOfSession out into DateRange
+			if !n.IsDateOfSession() {
+				return true
+			}
+			for _, e := range path {
+				if e.Name == "steps" && e.Index > 0 {
+					return fail(path, "dateOfSession is only allowed in the first step of a sequence")
+				}
+			}
+			if _, derr := n.DateOfSession(); derr != nil {
+				return fail(path, derr.Error())
+			}
+			if or, _ := path.Parent().(OrExpression); len(or) > 1 {
+				return fail(path, "dateOfSession cannot be combined with other expressions by OR")
+			}
+			return fail(path, "dateOfSession is only allowed as the first condition of a segment")
+		}
+		return true
+	})
+	return err
+}
+
+// checkDateRange checks the DateRange and the AndExpression of a condition or a step.
+func checkDateRange(path Path, dr *DateRange, ae AndExpression, fail func(Path, string) bool) bool {
+	if dr != nil {
+		if err := dr.Validate(); err != nil {
+			return fail(path, err.Error())
 		}
-		pos := []int{idx[2], idx[3]}
-		ret[i*2] = s[last[1]:pos[0]]
-		ret[i*2+1] = s[pos[0]:pos[1]]
-		last = pos
+	} else if len(ae) == 0 {
+		return fail(path, "no expression")
 	}
-	ret[len(ret)-1] = s[last[1]:]
-	return ret
+	return true
 }
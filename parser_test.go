@@ -0,0 +1,95 @@
+package gasegment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEdgeCases(t *testing.T) {
+	table := []struct {
+		definition string
+		expected   Segments
+	}{
+		{
+			// escaped backslash followed by a separator
+			definition: `users::condition::ga:pagePath==a\\;ga:pagePath==b`,
+			expected: NewSegments(Segment{
+				Scope: UserScope,
+				Type:  ConditionSegment,
+				Condition: Condition{
+					AndExpression: NewAndExpression(
+						NewOrExpression(Expression{Target: "ga:pagePath", Operator: Equal, Value: `a\`}),
+						NewOrExpression(Expression{Target: "ga:pagePath", Operator: Equal, Value: "b"}),
+					),
+				},
+			}),
+		},
+		{
+			// escaped backslash followed by an escaped separator
+			definition: `users::condition::ga:pagePath==a\\\;b`,
+			expected: NewSegments(Segment{
+				Scope: UserScope,
+				Type:  ConditionSegment,
+				Condition: Condition{
+					AndExpression: NewSingleAndExpression(Expression{Target: "ga:pagePath", Operator: Equal, Value: `a\;b`}),
+				},
+			}),
+		},
+		{
+			// operator strings inside a value
+			definition: `sessions::condition::ga:pagePath=@a==b<>c!~d`,
+			expected: NewSegments(Segment{
+				Scope: SessionScope,
+				Type:  ConditionSegment,
+				Condition: Condition{
+					AndExpression: NewSingleAndExpression(Expression{Target: "ga:pagePath", Operator: ContainsSubstring, Value: "a==b<>c!~d"}),
+				},
+			}),
+		},
+		{
+			// segment keywords inside an escaped value
+			definition: `sessions::sequence::ga:pagePath==a\;condition::b;->>perHit::ga:hits>1`,
+			expected: NewSegments(Segment{
+				Scope: SessionScope,
+				Type:  SequenceSegment,
+				Sequence: Sequence{
+					SequenceSteps: NewSequenceSteps(SequenceStep{
+						Type:          FirstStep,
+						AndExpression: NewSingleAndExpression(Expression{Target: "ga:pagePath", Operator: Equal, Value: "a;condition::b"}),
+					}, SequenceStep{
+						Type:          Precedes,
+						AndExpression: NewSingleAndExpression(Expression{MetricScope: PerHit, Target: "ga:hits", Operator: GreaterThan, Value: "1"}),
+					}),
+				},
+			}),
+		},
+	}
+
+	for _, c := range table {
+		act, err := Parse(c.definition)
+		if err != nil {
+			t.Errorf("%s: %s", c.definition, err)
+			continue
+		}
+		if !reflect.DeepEqual(act, c.expected) {
+			t.Errorf("failed to parse %s\nexpected: %v\nactual:   %v", c.definition, c.expected, act)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	table := []string{
+		"",
+		"users::",
+		"users::condition::",
+		"users::condition::ga:pagePath==a;",
+		"users::condition::ga:pagePath==a,",
+		"users::condition::ga:pagePath==a;->ga:pagePath==b",
+		"users::sequence::!^",
+	}
+	for _, def := range table {
+		if _, err := Parse(def); err == nil {
+			t.Errorf("parse '%s' must be error", def)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package gasegment
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// SegmentParameter is the name of the Core Reporting API query parameter holding a segment.
+const SegmentParameter = "segment"
+
+// MaxRequestURLLength is the maximum length of a Core Reporting API request URL.
+const MaxRequestURLLength = 2000
+
+var ErrNoSegmentParameter = errors.New("no segment parameter")
+
+type URLTooLongError struct {
+	Length int
+	Limit  int
+}
+
+func (e *URLTooLongError) Error() string {
+	return fmt.Sprintf("request URL is too long: %d characters (limit %d)", e.Length, e.Limit)
+}
+
+// ParseQueryValue parses a percent-encoded segment parameter value,
+// e.g. `users%3A%3Acondition%3A%3Aga%3ApagePath%3D%3D%2Fabc`.
+func ParseQueryValue(value string) (Segments, error) {
+	definition, err := url.QueryUnescape(value)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(definition)
+}
+
+// FromValues parses the segment parameter of decoded query values.
+func FromValues(values url.Values) (Segments, error) {
+	definitions, ok := values[SegmentParameter]
+	if !ok || len(definitions) == 0 || definitions[0] == "" {
+		return nil, ErrNoSegmentParameter
+	}
+	return Parse(definitions[0])
+}
+
+// FromURL parses the segment parameter of a Core Reporting API request URL.
+func FromURL(rawurl string) (Segments, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	return FromValues(values)
+}
+
+// QueryValue returns the definition escaped for use as the segment parameter
+// value of the request URL rawurl. It returns a *URLTooLongError along with the
+// value if the request URL with it, as built by RequestURL, exceeds MaxRequestURLLength.
+func (scs Segments) QueryValue(rawurl string) (string, error) {
+	v := url.QueryEscape(scs.DefString())
+	if _, err := scs.RequestURL(rawurl); err != nil {
+		var tooLong *URLTooLongError
+		if errors.As(err, &tooLong) {
+			return v, err
+		}
+		return "", err
+	}
+	return v, nil
+}
+
+// RequestURL sets the segment parameter on the request URL.
+// It returns a *URLTooLongError along with the URL if the result exceeds MaxRequestURLLength.
+func (scs Segments) RequestURL(rawurl string) (string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", err
+	}
+	values.Set(SegmentParameter, scs.DefString())
+	u.RawQuery = values.Encode()
+	s := u.String()
+	if len(s) > MaxRequestURLLength {
+		return s, &URLTooLongError{Length: len(s), Limit: MaxRequestURLLength}
+	}
+	return s, nil
+}
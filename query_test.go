@@ -0,0 +1,89 @@
+package gasegment
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseQueryValue(t *testing.T) {
+	expected := "users::condition::ga:pagePath==/abc"
+
+	ss, err := ParseQueryValue("users%3A%3Acondition%3A%3Aga%3ApagePath%3D%3D%2Fabc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := ss.DefString(); act != expected {
+		t.Errorf("unexpected definition %s", act)
+	}
+
+	ss, err = FromURL("https://www.googleapis.com/analytics/v3/data/ga?ids=ga%3A12345&metrics=ga%3Asessions&segment=users%3A%3Acondition%3A%3Aga%3ApagePath%3D%3D%2Fabc&start-date=7daysAgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := ss.DefString(); act != expected {
+		t.Errorf("unexpected definition %s", act)
+	}
+
+	if _, err := FromURL("https://www.googleapis.com/analytics/v3/data/ga?ids=ga%3A12345"); err != ErrNoSegmentParameter {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := FromValues(url.Values{}); err != ErrNoSegmentParameter {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestQueryValue(t *testing.T) {
+	for _, def := range TestCheckDefs {
+		ss := MustParse(def)
+		v, err := ss.QueryValue("https://www.googleapis.com/analytics/v3/data/ga")
+		var tooLong *URLTooLongError
+		if err != nil && !errors.As(err, &tooLong) {
+			t.Errorf("%s: %s", def, err)
+			continue
+		}
+		parsed, err := ParseQueryValue(v)
+		if err != nil {
+			t.Errorf("%s: %s", def, err)
+			continue
+		}
+		if act := parsed.DefString(); act != def {
+			t.Errorf("check failed\n\texpected: %s\n\tactual:   %s", def, act)
+		}
+	}
+}
+
+func TestRequestURL(t *testing.T) {
+	base := "https://www.googleapis.com/analytics/v3/data/ga?ids=ga%3A12345&metrics=ga%3Asessions"
+	ss := MustParse(`sessions::condition::ga:pagePath==/a b&c\;d`)
+	u, err := ss.RequestURL(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := FromURL(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.DefString() != ss.DefString() {
+		t.Errorf("unexpected definition %s", parsed.DefString())
+	}
+
+	long := MustParse("sessions::condition::ga:pagePath==/" + strings.Repeat("a", MaxRequestURLLength))
+	var tooLong *URLTooLongError
+	if _, err := long.RequestURL(base); !errors.As(err, &tooLong) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := long.QueryValue(base); !errors.As(err, &tooLong) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// the value alone fits, but not with the rest of the URL
+	short := MustParse("sessions::condition::ga:pagePath==/" + strings.Repeat("a", MaxRequestURLLength-len(base)))
+	if v, err := short.QueryValue(base); len(v) > MaxRequestURLLength || !errors.As(err, &tooLong) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := short.QueryValue("%"); err == nil || errors.As(err, &tooLong) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package gasegment
+
+// Rewrite returns a copy of the segments with every expression passed to fn.
+//
+// If fn returns false, the expression is kept as is. Otherwise it is replaced by the
+// returned expressions, which are OR'ed in its place: none drops the expression, and
+// more than one expands it. Groups left empty are collapsed: an empty OR group is
+// removed from its AND group, a condition segment without expressions and a sequence
+// segment without steps are removed, and when the first step of a sequence is removed
+// the next step becomes the first one. The input segments are not modified.
+func Rewrite(scs Segments, fn func(Expression) ([]Expression, bool)) Segments {
+	result := make(Segments, 0, len(scs))
+	for _, sg := range scs {
+		switch sg.Type {
+		case ConditionSegment:
+			sg.Condition.AndExpression = rewriteAndExpression(sg.Condition.AndExpression, fn)
+			if len(sg.Condition.AndExpression) == 0 && sg.Condition.DateRange == nil {
+				continue
+			}
+		case SequenceSegment:
+			steps := make(SequenceSteps, 0, len(sg.Sequence.SequenceSteps))
+			for _, step := range sg.Sequence.SequenceSteps {
+				step.AndExpression = rewriteAndExpression(step.AndExpression, fn)
+				if len(step.AndExpression) == 0 && step.DateRange == nil {
+					continue
+				}
+				if len(steps) == 0 {
+					step.Type = FirstStep
+				}
+				steps = append(steps, step)
+			}
+			if len(steps) == 0 {
+				continue
+			}
+			sg.Sequence.SequenceSteps = steps
+		}
+		result = append(result, sg)
+	}
+	return result
+}
+
+func rewriteAndExpression(ae AndExpression, fn func(Expression) ([]Expression, bool)) AndExpression {
+	result := make(AndExpression, 0, len(ae))
+	for _, or := range ae {
+		ors := make(OrExpression, 0, len(or))
+		for _, e := range or {
+			if es, ok := fn(e); ok {
+				ors = append(ors, es...)
+			} else {
+				ors = append(ors, e)
+			}
+		}
+		if len(ors) > 0 {
+			result = append(result, ors)
+		}
+	}
+	return result
+}
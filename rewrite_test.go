@@ -0,0 +1,82 @@
+package gasegment
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	table := []struct {
+		definition string
+		fn         func(Expression) ([]Expression, bool)
+		expected   string
+	}{
+		{
+			// rename
+			"users::condition::ga:pagePath==/a,ga:hits>1;sessions::sequence::ga:pagePath==/b;->ga:pagePath==/c",
+			func(e Expression) ([]Expression, bool) {
+				if e.Target != "ga:pagePath" {
+					return nil, false
+				}
+				e.Target = "ga:landingPagePath"
+				return []Expression{e}, true
+			},
+			"users::condition::ga:landingPagePath==/a,ga:hits>1;sessions::sequence::ga:landingPagePath==/b;->ga:landingPagePath==/c",
+		},
+		{
+			// bump a threshold
+			"users::condition::ga:hits>1;perSession::ga:sessionDuration>60",
+			func(e Expression) ([]Expression, bool) {
+				v, err := e.Number()
+				if err != nil {
+					return nil, false
+				}
+				e.Value = strconv.Itoa(int(v) * 10)
+				return []Expression{e}, true
+			},
+			"users::condition::ga:hits>10;perSession::ga:sessionDuration>600",
+		},
+		{
+			// expand
+			"users::condition::ga:pagePath=@/a;ga:hits>1",
+			func(e Expression) ([]Expression, bool) {
+				if e.Operator != ContainsSubstring {
+					return nil, false
+				}
+				upper := e
+				upper.Value = strings.ToUpper(e.Value)
+				return []Expression{e, upper}, true
+			},
+			"users::condition::ga:pagePath=@/a,ga:pagePath=@/A;ga:hits>1",
+		},
+		{
+			// drop and collapse
+			"users::condition::ga:pagePath==/a,ga:hits>1;ga:pagePath==/b;sessions::condition::!ga:pagePath==/c;users::sequence::^ga:pagePath==/d;->ga:hits>1;->>ga:hits>2",
+			func(e Expression) ([]Expression, bool) {
+				return nil, e.Target == "ga:pagePath"
+			},
+			"users::condition::ga:hits>1;sequence::^ga:hits>1;->>ga:hits>2",
+		},
+		{
+			// drop everything
+			"users::condition::ga:pagePath==/a;sessions::sequence::ga:pagePath==/b",
+			func(e Expression) ([]Expression, bool) {
+				return nil, true
+			},
+			"",
+		},
+	}
+
+	for _, c := range table {
+		ss := MustParse(c.definition)
+		before := ss.DefString()
+		act := Rewrite(ss, c.fn)
+		if def := act.DefString(); def != c.expected {
+			t.Errorf("%s\n\texpected: %s\n\tactual:   %s", c.definition, c.expected, def)
+		}
+		if ss.DefString() != before {
+			t.Errorf("%s: input is modified to %s", c.definition, ss.DefString())
+		}
+	}
+}
@@ -54,6 +54,7 @@ func (ct SegmentType) String() string {
 const (
 	ConditionSegment = SegmentType("condition::")
 	SequenceSegment  = SegmentType("sequence::")
+	ReferenceSegment = SegmentType("gaid::")
 )
 
 type MetricScope string
@@ -67,6 +68,7 @@ const (
 	PerHit     = MetricScope("perHit::")
 	PerSession = MetricScope("perSession::")
 	PerUser    = MetricScope("perUser::")
+	PerProduct = MetricScope("perProduct::")
 )
 
 type SequenceStepType string
@@ -144,6 +146,9 @@ type Segment struct {
 	Type      SegmentType
 	Condition Condition
 	Sequence  Sequence
+	Reference SegmentRef
+
+	Source *Source // set by ParseOptions.RecordSource
 }
 
 func (sc *Segment) DefString() string {
@@ -156,13 +161,17 @@ func (sc *Segment) DefStringWithoutScope() string {
 		return sc.Type.String() + sc.Condition.DefString()
 	case SequenceSegment:
 		return sc.Type.String() + sc.Sequence.DefString()
+	case ReferenceSegment:
+		return sc.Type.String() + sc.Reference.ID
 	default:
 		return ""
 	}
 }
 
 type Condition struct {
-	Exclude       bool
+	Exclude bool
+	// DateRange is the dateOfSession constraint, written as the first condition.
+	DateRange     *DateRange
 	AndExpression AndExpression
 }
 
@@ -171,10 +180,16 @@ func (c Condition) DefString() string {
 	if c.Exclude {
 		buf = append(buf, "!")
 	}
-	buf = append(buf, c.AndExpression.DefString())
+	buf = append(buf, c.Groups().DefString())
 	return strings.Join(buf, "")
 }
 
+// Groups returns the AND groups as written in a definition, with the
+// dateOfSession expression of DateRange, if any, first.
+func (c Condition) Groups() AndExpression {
+	return withDateRange(c.DateRange, c.AndExpression)
+}
+
 type AndExpression []OrExpression
 
 func (a AndExpression) DefString() string {
@@ -212,17 +227,12 @@ type Expression struct {
 	Target      DimensionOrMetric
 	Operator    Operator
 	Value       string
-}
 
-func (c Expression) EscapedValue() string {
-	return EscapeExpressionValue(c.Value)
+	Source *Source // set by ParseOptions.RecordSource
 }
 
-func EscapeExpressionValue(s string) string {
-	return strings.Replace(strings.Replace(s, ";", `\;`, -1), ",", `\,`, -1)
-}
-func UnEscapeExpressionValue(s string) string {
-	return strings.Replace(strings.Replace(s, `\;`, ";", -1), `\,`, ",", -1)
+func (c Expression) EscapedValue() string {
+	return EscapeValue(c.Operator, c.Value)
 }
 
 func (c Expression) DefString() string {
@@ -248,8 +258,12 @@ func (s Sequence) DefString() string {
 }
 
 type SequenceStep struct {
-	Type          SequenceStepType
+	Type SequenceStepType
+	// DateRange is the dateOfSession constraint, only allowed on the first step.
+	DateRange     *DateRange
 	AndExpression AndExpression
+
+	Source *Source // set by ParseOptions.RecordSource
 }
 
 type SequenceSteps []SequenceStep
@@ -267,5 +281,11 @@ func (ss SequenceSteps) DefString() string {
 }
 
 func (ss SequenceStep) DefString() string {
-	return strings.Join([]string{ss.Type.String(), ss.AndExpression.DefString()}, "")
+	return strings.Join([]string{ss.Type.String(), ss.Groups().DefString()}, "")
+}
+
+// Groups returns the AND groups as written in a definition, with the
+// dateOfSession expression of DateRange, if any, first.
+func (ss SequenceStep) Groups() AndExpression {
+	return withDateRange(ss.DateRange, ss.AndExpression)
 }
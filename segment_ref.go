@@ -0,0 +1,132 @@
+package gasegment
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SegmentRef refers to a built-in (`gaid::-1`) or saved (`gaid::<id>`) segment.
+type SegmentRef struct {
+	ID string
+}
+
+func (r SegmentRef) String() string {
+	return ReferenceSegment.String() + r.ID
+}
+
+// IsBuiltin reports whether the reference points to one of the built-in segments.
+func (r SegmentRef) IsBuiltin() bool {
+	_, ok := LookupBuiltinSegment(r.ID)
+	return ok
+}
+
+// NewSegmentRef creates a `gaid::<id>` segment.
+func NewSegmentRef(id string) Segment {
+	return Segment{
+		Type:      ReferenceSegment,
+		Reference: SegmentRef{ID: id},
+	}
+}
+
+// Reference returns the segment reference if the segments are a single `gaid::` reference.
+func (scs Segments) Reference() (SegmentRef, bool) {
+	if len(scs) == 1 && scs[0].Type == ReferenceSegment {
+		return scs[0].Reference, true
+	}
+	return SegmentRef{}, false
+}
+
+type BuiltinSegment struct {
+	ID         string
+	Name       string
+	Definition string // equivalent dynamic definition. "" for all users
+}
+
+var builtinSegments = []BuiltinSegment{
+	{"-1", "All Users", ""},
+	{"-2", "New Users", "sessions::condition::ga:userType==New Visitor"},
+	{"-3", "Returning Users", "sessions::condition::ga:userType==Returning Visitor"},
+	{"-4", "Paid Traffic", "sessions::condition::ga:medium=~^(cpc|ppc|cpa|cpm|cpv|cpp)$"},
+	{"-5", "Organic Traffic", "sessions::condition::ga:medium==organic"},
+	{"-6", "Search Traffic", "sessions::condition::ga:medium=~^(cpc|ppc|cpa|cpm|cpv|cpp|organic)$"},
+	{"-7", "Direct Traffic", "sessions::condition::ga:medium==(none)"},
+	{"-8", "Referral Traffic", "sessions::condition::ga:medium==referral"},
+	{"-9", "Sessions with Conversions", "sessions::condition::ga:goalCompletionsAll>0"},
+	{"-10", "Sessions with Transactions", "sessions::condition::ga:transactions>0"},
+	{"-11", "Mobile and Tablet Traffic", "sessions::condition::ga:deviceCategory==mobile,ga:deviceCategory==tablet"},
+	{"-12", "Non-bounce Sessions", "sessions::condition::ga:bounces==0"},
+	{"-13", "Tablet Traffic", "sessions::condition::ga:deviceCategory==tablet"},
+	{"-14", "Mobile Traffic", "sessions::condition::ga:deviceCategory==mobile"},
+	{"-15", "Tablet and Desktop Traffic", "sessions::condition::ga:deviceCategory==tablet,ga:deviceCategory==desktop"},
+	{"-16", "Android Traffic", "sessions::condition::ga:operatingSystem==Android"},
+	{"-17", "iOS Traffic", "sessions::condition::ga:operatingSystem=~^(iOS|iPad|iPhone)$"},
+	{"-18", "Other Traffic (Neither iOS nor Android)", "sessions::condition::ga:operatingSystem!~^(Android|iOS|iPad|iPhone)$"},
+	{"-19", "Bounced Sessions", "sessions::condition::ga:bounces>0"},
+}
+
+// BuiltinSegments returns the catalog of built-in segments, `gaid::-1` to `gaid::-19`.
+func BuiltinSegments() []BuiltinSegment {
+	ret := make([]BuiltinSegment, len(builtinSegments))
+	copy(ret, builtinSegments)
+	return ret
+}
+
+func LookupBuiltinSegment(id string) (BuiltinSegment, bool) {
+	for _, bs := range builtinSegments {
+		if bs.ID == id {
+			return bs, true
+		}
+	}
+	return BuiltinSegment{}, false
+}
+
+var ErrUnknownSegment = errors.New("unknown segment")
+
+// SegmentResolver resolves a segment id to its definition.
+type SegmentResolver interface {
+	ResolveSegment(id string) (Segments, error)
+}
+
+type SegmentResolverFunc func(id string) (Segments, error)
+
+func (f SegmentResolverFunc) ResolveSegment(id string) (Segments, error) {
+	return f(id)
+}
+
+// BuiltinResolver resolves the built-in segments, and returns ErrUnknownSegment for others.
+var BuiltinResolver SegmentResolver = SegmentResolverFunc(func(id string) (Segments, error) {
+	bs, ok := LookupBuiltinSegment(id)
+	if !ok {
+		return nil, ErrUnknownSegment
+	}
+	if bs.Definition == "" {
+		return Segments{}, nil
+	}
+	return Parse(bs.Definition)
+})
+
+// Expand replaces `gaid::` references with their definitions.
+// BuiltinResolver is used if r is nil.
+func (scs Segments) Expand(r SegmentResolver) (Segments, error) {
+	if r == nil {
+		r = BuiltinResolver
+	}
+	ret := Segments{}
+	for _, sc := range scs {
+		if sc.Type != ReferenceSegment {
+			ret = append(ret, sc)
+			continue
+		}
+		resolved, err := r.ResolveSegment(sc.Reference.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sc.Reference, err)
+		}
+		for _, rs := range resolved {
+			if rs.Type == ReferenceSegment {
+				return nil, fmt.Errorf("%s: resolved to another reference %s", sc.Reference, rs.Reference)
+			}
+		}
+		ret = append(ret, resolved...)
+	}
+	return ret, nil
+}
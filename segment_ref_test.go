@@ -0,0 +1,85 @@
+package gasegment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSegmentRef(t *testing.T) {
+	for _, def := range []string{"gaid::-3", "gaid::Ab3dEfGhIjKlMnOpQrStUv"} {
+		ss, err := Parse(def)
+		if err != nil {
+			t.Errorf("%s: %s", def, err)
+			continue
+		}
+		ref, ok := ss.Reference()
+		if !ok || ref.String() != def {
+			t.Errorf("%s: unexpected reference %v", def, ss)
+		}
+		if act := ss.DefString(); act != def {
+			t.Errorf("check failed\n\texpected: %s\n\tactual:   %s", def, act)
+		}
+	}
+
+	for _, def := range []string{
+		"gaid::",
+		"users::gaid::-3",
+		"gaid::-3;users::condition::ga:pagePath==/abc",
+		"users::condition::ga:pagePath==/abc;gaid::-3",
+	} {
+		if _, err := Parse(def); err == nil {
+			t.Errorf("parse '%s' must be error", def)
+		}
+	}
+}
+
+func TestBuiltinSegments(t *testing.T) {
+	bss := BuiltinSegments()
+	if len(bss) != 19 {
+		t.Errorf("unexpected number of builtin segments %d", len(bss))
+	}
+	for _, bs := range bss {
+		ss := NewSegments(NewSegmentRef(bs.ID))
+		expanded, err := ss.Expand(nil)
+		if err != nil {
+			t.Errorf("%s: %s", bs.ID, err)
+			continue
+		}
+		if act := expanded.DefString(); act != bs.Definition {
+			t.Errorf("%s: unexpected expansion %s", bs.ID, act)
+		}
+		if err := expanded.Validate(); err != nil {
+			t.Errorf("%s: %s", bs.ID, err)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	saved := map[string]string{
+		"abc": "users::condition::ga:pagePath==/abc",
+		"ref": "gaid::-3",
+	}
+	resolver := SegmentResolverFunc(func(id string) (Segments, error) {
+		if def, ok := saved[id]; ok {
+			return Parse(def)
+		}
+		return BuiltinResolver.ResolveSegment(id)
+	})
+
+	expanded, err := MustParse("gaid::abc").Expand(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := expanded.DefString(); act != saved["abc"] {
+		t.Errorf("unexpected expansion %s", act)
+	}
+	if _, err := MustParse("gaid::-8").Expand(resolver); err != nil {
+		t.Error(err)
+	}
+	if _, err := MustParse("gaid::ref").Expand(resolver); err == nil {
+		t.Error("nested reference must be error")
+	}
+	if _, err := MustParse("gaid::unknown").Expand(resolver); !errors.Is(err, ErrUnknownSegment) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"flag"
 	"net/http"
 	"reflect"
-	"regexp"
 	"testing"
 
 	analytics "google.golang.org/api/analytics/v3"
@@ -52,22 +51,6 @@ func TestSegment(t *testing.T) {
 	}
 }
 
-func checkSplit(t *testing.T, s string, r *regexp.Regexp, expected []string) {
-	act := splitByFirstRegexpGroup(s, r)
-	if !reflect.DeepEqual(act, expected) {
-		t.Errorf("splitByFirstRegexpGroup failed. actual: %v, expected: %v", act, expected)
-	}
-}
-
-func TestSplitByFirstRegexpGroup(t *testing.T) {
-	p := regexp.MustCompile(`aaa(bbb)ccc`)
-	checkSplit(t, "abc", p, []string{"abc"})
-	checkSplit(t, "hogehogeaaabbbcccfugafuga", p, []string{"hogehogeaaa", "bbb", "cccfugafuga"})
-	checkSplit(t, "hogehogeaaabbbcccfugafugaaaabbbccc!!!", p, []string{"hogehogeaaa", "bbb", "cccfugafugaaaa", "bbb", "ccc!!!"})
-
-	checkSplit(t, "a;->b;->>c", SequenceSeparatorRe, []string{"a", ";->", "b", ";->>", "c"})
-}
-
 func TestSortScope(t *testing.T) {
 	input := `sessions::condition::ga:deviceCategory==desktop;users::condition::ga:pagePath!~^\Q/lk/\E,ga:pagePath!~^\Q/netacho/\E;sessions::condition::!ga:channelGrouping==(none);condition::ga:pagePath=~^\Q/inquiry/\E,ga:pagePath=~^\Q/inquiry/\E;condition::ga:deviceCategory==desktop;users::condition::ga:pagePath!~^\Q/lk/\E,ga:pagePath!~^\Q/netacho/\E;sessions::condition::!ga:channelGrouping==(none);condition::ga:goal4Completions>0`
 
@@ -0,0 +1,187 @@
+package segmentyaml
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/wacul/gasegment"
+	"gopkg.in/yaml.v3"
+)
+
+// Dump writes a library of definitions in the format read by Load.
+func Dump(defs []Definition) ([]byte, error) {
+	list := seq()
+	for _, def := range defs {
+		sgs, err := segmentsNode(def.Segments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", def.Name, err)
+		}
+		n := mapNode("name", str2node(def.Name))
+		if def.Description != "" {
+			n.Content = append(n.Content, str2node("description"), str2node(def.Description))
+		}
+		n.Content = append(n.Content, str2node("definition"), sgs)
+		list.Content = append(list.Content, n)
+	}
+	return encode(mapNode("segments", list))
+}
+
+// DumpSegments writes a single definition in the format read by LoadSegments.
+func DumpSegments(scs gasegment.Segments) ([]byte, error) {
+	n, err := segmentsNode(scs)
+	if err != nil {
+		return nil, err
+	}
+	return encode(n)
+}
+
+func encode(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func segmentsNode(scs gasegment.Segments) (*yaml.Node, error) {
+	list := seq()
+	for _, sg := range scs {
+		var body *yaml.Node
+		switch sg.Type {
+		case gasegment.ConditionSegment:
+			and, err := andNode(sg.Condition.Groups())
+			if err != nil {
+				return nil, err
+			}
+			c := mapNode()
+			if sg.Condition.Exclude {
+				c.Content = append(c.Content, str2node("exclude"), boolNode(true))
+			}
+			c.Content = append(c.Content, str2node("all"), and)
+			body = mapNode("condition", c)
+		case gasegment.SequenceSegment:
+			s := mapNode()
+			if sg.Sequence.Not {
+				s.Content = append(s.Content, str2node("not"), boolNode(true))
+			}
+			if sg.Sequence.FirstHitMatchesFirstStep {
+				s.Content = append(s.Content, str2node("firstHitMatchesFirstStep"), boolNode(true))
+			}
+			steps := seq()
+			for _, step := range sg.Sequence.SequenceSteps {
+				and, err := andNode(step.Groups())
+				if err != nil {
+					return nil, err
+				}
+				n := mapNode()
+				if step.Type == gasegment.ImmediatelyPrecedes {
+					n.Content = append(n.Content, str2node("immediately"), boolNode(true))
+				}
+				n.Content = append(n.Content, str2node("all"), and)
+				steps.Content = append(steps.Content, n)
+			}
+			s.Content = append(s.Content, str2node("steps"), steps)
+			body = mapNode("sequence", s)
+		case gasegment.ReferenceSegment:
+			list.Content = append(list.Content, mapNode("gaid", str2node(sg.Reference.ID)))
+			continue
+		default:
+			return nil, fmt.Errorf("unknown segment type %q", sg.Type)
+		}
+		switch sg.Scope {
+		case gasegment.UserScope:
+			body = mapNode("users", body)
+		case gasegment.SessionScope:
+			body = mapNode("sessions", body)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown scope %q", sg.Scope)
+		}
+		list.Content = append(list.Content, body)
+	}
+	return list, nil
+}
+
+func andNode(ae gasegment.AndExpression) (*yaml.Node, error) {
+	list := seq()
+	for _, or := range ae {
+		if len(or) == 1 {
+			e, err := expressionNode(or[0])
+			if err != nil {
+				return nil, err
+			}
+			list.Content = append(list.Content, e)
+			continue
+		}
+		group := seq()
+		for _, e := range or {
+			n, err := expressionNode(e)
+			if err != nil {
+				return nil, err
+			}
+			group.Content = append(group.Content, n)
+		}
+		list.Content = append(list.Content, mapNode("any", group))
+	}
+	return list, nil
+}
+
+func expressionNode(e gasegment.Expression) (*yaml.Node, error) {
+	n := mapNode("target", str2node(e.Target.String()))
+	n.Style = yaml.FlowStyle
+	if e.MetricScope != gasegment.Default {
+		name := ""
+		for k, ms := range metricScopes {
+			if ms == e.MetricScope {
+				name = k
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("unknown metric scope %q", e.MetricScope)
+		}
+		n.Content = append(n.Content, str2node("metricScope"), str2node(name))
+	}
+	if !operators[e.Operator] {
+		return nil, fmt.Errorf("unknown operator %q", e.Operator)
+	}
+	n.Content = append(n.Content, str2node("operator"), str2node(e.Operator.String()))
+	switch vs := gasegment.SplitRangeValue(e.Value); {
+	case e.IsList():
+		items := seq()
+		items.Style = yaml.FlowStyle
+		for _, item := range gasegment.SplitListValue(e.Value) {
+			items.Content = append(items.Content, str2node(item))
+		}
+		n.Content = append(n.Content, str2node("values"), items)
+	case e.IsRange() && len(vs) == 2:
+		n.Content = append(n.Content, str2node("min"), str2node(vs[0]), str2node("max"), str2node(vs[1]))
+	default:
+		n.Content = append(n.Content, str2node("value"), str2node(e.Value))
+	}
+	return n, nil
+}
+
+func mapNode(kvs ...interface{}) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(kvs); i += 2 {
+		n.Content = append(n.Content, str2node(kvs[i].(string)), kvs[i+1].(*yaml.Node))
+	}
+	return n
+}
+
+func seq() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode}
+}
+
+func str2node(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+func boolNode(b bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(b)}
+}
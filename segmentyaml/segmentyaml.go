@@ -0,0 +1,529 @@
+// Package segmentyaml reads and writes segment definitions in a YAML format
+// meant to be maintained by hand and reviewed in diffs:
+//
+//	segments:
+//	  - name: Buyers from search
+//	    description: Users who bought after a search, on mobile
+//	    definition:
+//	      - users:
+//	          condition:
+//	            all:
+//	              - {target: ga:transactions, metricScope: perUser, operator: ">", value: "0"}
+//	              - any:
+//	                  - {target: ga:deviceCategory, operator: "==", value: mobile}
+//	                  - {target: ga:deviceCategory, operator: "==", value: tablet}
+//	      - sessions:
+//	          sequence:
+//	            steps:
+//	              - all:
+//	                  - {target: ga:pagePath, operator: "=~", value: ^/search}
+//	              - immediately: true
+//	                all:
+//	                  - {target: ga:pagePath, operator: "==", value: /cart}
+//
+// A definition is the list of its segments, each under `users:` or `sessions:`,
+// or directly `condition:` or `sequence:` to take the scope of the previous
+// segment, or `gaid:` for a reference. `all:` lists the AND groups of a
+// condition or a step; an item is an expression or an `any:` list of OR'd
+// expressions. An expression has the same properties as in the JSON encoding
+// of gasegment (schema/segments.schema.json): values are unescaped, in-lists
+// have `values:` and ranges `min:` and `max:`. Conversions to and from
+// gasegment.Segments are lossless. Loaded segments are checked like
+// gasegment.Parse and Segments.Validate check a definition, e.g. `all:` can't be
+// empty and dateOfSession is only allowed as the first item of the `all:` of a
+// condition or of the first step of a sequence.
+package segmentyaml
+
+import (
+	"fmt"
+
+	"github.com/wacul/gasegment"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition is a named segment definition of a library.
+type Definition struct {
+	Name        string
+	Description string
+	Segments    gasegment.Segments
+}
+
+// Error is an error in a YAML document at Line and Column (1-based).
+type Error struct {
+	Line, Column int
+	Message      string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func errorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &Error{Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+var metricScopes = map[string]gasegment.MetricScope{
+	"perHit":     gasegment.PerHit,
+	"perSession": gasegment.PerSession,
+	"perUser":    gasegment.PerUser,
+	"perProduct": gasegment.PerProduct,
+}
+
+var operators = map[gasegment.Operator]bool{
+	gasegment.Equal:                true,
+	gasegment.NotEqual:             true,
+	gasegment.LessThan:             true,
+	gasegment.LessThanEqual:        true,
+	gasegment.GreaterThan:          true,
+	gasegment.GreaterThanEqual:     true,
+	gasegment.Between:              true,
+	gasegment.NotBetween:           true,
+	gasegment.InList:               true,
+	gasegment.NotInList:            true,
+	gasegment.ContainsSubstring:    true,
+	gasegment.NotContainsSubstring: true,
+	gasegment.Regexp:               true,
+	gasegment.NotRegexp:            true,
+}
+
+// Load reads a library of definitions under `segments:`. Names must be unique.
+func Load(data []byte) ([]Definition, error) {
+	root, err := document(data)
+	if err != nil {
+		return nil, err
+	}
+	var defs []Definition
+	err = mapping(root, func(key, value *yaml.Node) error {
+		if key.Value != "segments" {
+			return errorf(key, "unknown key %q", key.Value)
+		}
+		names := map[string]bool{}
+		return sequence(value, func(n *yaml.Node) error {
+			def, err := definition(n)
+			if err != nil {
+				return err
+			}
+			if names[def.Name] {
+				return errorf(n, "duplicate name %q", def.Name)
+			}
+			names[def.Name] = true
+			defs = append(defs, def)
+			return nil
+		})
+	})
+	return defs, err
+}
+
+// LoadSegments reads a single definition, a list of segments.
+func LoadSegments(data []byte) (gasegment.Segments, error) {
+	root, err := document(data)
+	if err != nil {
+		return nil, err
+	}
+	return segments(root)
+}
+
+func document(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, &Error{Line: 1, Column: 1, Message: "empty document"}
+	}
+	return doc.Content[0], nil
+}
+
+func definition(n *yaml.Node) (Definition, error) {
+	var def Definition
+	var hasSegments bool
+	err := mapping(n, func(key, value *yaml.Node) error {
+		var err error
+		switch key.Value {
+		case "name":
+			def.Name, err = str(value)
+		case "description":
+			def.Description, err = str(value)
+		case "definition":
+			def.Segments, err = segments(value)
+			hasSegments = true
+		default:
+			err = errorf(key, "unknown key %q", key.Value)
+		}
+		return err
+	})
+	switch {
+	case err != nil:
+		return Definition{}, err
+	case def.Name == "":
+		return Definition{}, errorf(n, "definition has no name")
+	case !hasSegments:
+		return Definition{}, errorf(n, "%s has no definition", def.Name)
+	}
+	return def, nil
+}
+
+func segments(n *yaml.Node) (gasegment.Segments, error) {
+	scs := gasegment.Segments{}
+	var scope gasegment.SegmentScope
+	var ref *yaml.Node
+	err := sequence(n, func(n *yaml.Node) error {
+		sg, err := segment(n)
+		if err != nil {
+			return err
+		}
+		// like in a definition, a segment without scope has the scope of the previous one
+		if sg.Type == gasegment.ReferenceSegment {
+			if ref == nil {
+				ref = n
+			}
+		} else {
+			if sg.Scope == "" {
+				sg.Scope = scope
+			}
+			if sg.Scope == "" {
+				return errorf(n, "no segment scope (user:: or session::)")
+			}
+			scope = sg.Scope
+		}
+		scs = append(scs, sg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ref != nil && len(scs) > 1 {
+		return nil, errorf(ref, "gaid:: segment cannot be combined with other segments")
+	}
+	return scs, nil
+}
+
+func segment(n *yaml.Node) (gasegment.Segment, error) {
+	key, value, err := single(n, "users, sessions, condition, sequence or gaid")
+	if err != nil {
+		return gasegment.Segment{}, err
+	}
+	switch key.Value {
+	case "users", "sessions":
+		sg, err := scoped(value)
+		sg.Scope = map[string]gasegment.SegmentScope{"users": gasegment.UserScope, "sessions": gasegment.SessionScope}[key.Value]
+		return sg, err
+	case "gaid":
+		id, err := str(value)
+		if err == nil && id == "" {
+			err = errorf(value, "empty gaid")
+		}
+		return gasegment.NewSegmentRef(id), err
+	case "condition", "sequence":
+		return scoped(n)
+	}
+	return gasegment.Segment{}, errorf(key, "unknown key %q, expected users, sessions, condition, sequence or gaid", key.Value)
+}
+
+// scoped reads the condition or the sequence of a segment.
+func scoped(n *yaml.Node) (gasegment.Segment, error) {
+	key, value, err := single(n, "condition or sequence")
+	if err != nil {
+		return gasegment.Segment{}, err
+	}
+	switch key.Value {
+	case "condition":
+		c, err := condition(value)
+		return gasegment.Segment{Type: gasegment.ConditionSegment, Condition: c}, err
+	case "sequence":
+		s, err := sequenceSegment(value)
+		return gasegment.Segment{Type: gasegment.SequenceSegment, Sequence: s}, err
+	}
+	return gasegment.Segment{}, errorf(key, "unknown key %q, expected condition or sequence", key.Value)
+}
+
+func condition(n *yaml.Node) (gasegment.Condition, error) {
+	var c gasegment.Condition
+	var err error
+	c.AndExpression, err = andExpression(n, &dateOfSession{allowed: true}, func(key, value *yaml.Node) (err error) {
+		if key.Value != "exclude" {
+			return errorf(key, "unknown key %q", key.Value)
+		}
+		c.Exclude, err = boolean(value)
+		return err
+	})
+	c.DateRange, c.AndExpression = dateRange(c.AndExpression)
+	return c, err
+}
+
+func sequenceSegment(n *yaml.Node) (gasegment.Sequence, error) {
+	var s gasegment.Sequence
+	dos := &dateOfSession{}
+	err := mapping(n, func(key, value *yaml.Node) error {
+		var err error
+		switch key.Value {
+		case "not":
+			s.Not, err = boolean(value)
+		case "firstHitMatchesFirstStep":
+			s.FirstHitMatchesFirstStep, err = boolean(value)
+		case "steps":
+			err = sequence(value, func(n *yaml.Node) error {
+				dos.allowed = len(s.SequenceSteps) == 0
+				step, err := sequenceStep(n, dos)
+				s.SequenceSteps = append(s.SequenceSteps, step)
+				return err
+			})
+		default:
+			err = errorf(key, "unknown key %q", key.Value)
+		}
+		return err
+	})
+	if err == nil && len(s.SequenceSteps) == 0 {
+		err = errorf(n, "sequence has no steps")
+	}
+	return s, err
+}
+
+func sequenceStep(n *yaml.Node, dos *dateOfSession) (gasegment.SequenceStep, error) {
+	// only the first step may have a dateOfSession
+	first := dos.allowed
+	step := gasegment.SequenceStep{Type: gasegment.Precedes}
+	if first {
+		step.Type = gasegment.FirstStep
+	}
+	var err error
+	step.AndExpression, err = andExpression(n, dos, func(key, value *yaml.Node) error {
+		if key.Value != "immediately" {
+			return errorf(key, "unknown key %q", key.Value)
+		}
+		immediately, err := boolean(value)
+		if err != nil {
+			return err
+		}
+		if first && immediately {
+			return errorf(key, "the first step cannot immediately follow another step")
+		}
+		if immediately {
+			step.Type = gasegment.ImmediatelyPrecedes
+		}
+		return nil
+	})
+	if first {
+		step.DateRange, step.AndExpression = dateRange(step.AndExpression)
+	}
+	return step, err
+}
+
+// dateRange takes the dateOfSession expression, which dateOfSession.check
+// allows only alone in the first group, out of ae.
+func dateRange(ae gasegment.AndExpression) (*gasegment.DateRange, gasegment.AndExpression) {
+	if len(ae) == 0 || len(ae[0]) != 1 || !ae[0][0].IsDateOfSession() {
+		return nil, ae
+	}
+	dr, err := ae[0][0].DateOfSession()
+	if err != nil {
+		return nil, ae
+	}
+	return &dr, ae[1:]
+}
+
+// dateOfSession checks the placement of dateOfSession expressions in a segment
+// as gasegment.Parse does: alone in the first group of a condition or of the
+// first step.
+type dateOfSession struct {
+	allowed bool // in a condition or the first step
+}
+
+func (dos *dateOfSession) check(n *yaml.Node, e gasegment.Expression, first, ored bool) error {
+	if !e.IsDateOfSession() {
+		return nil
+	}
+	switch {
+	case !dos.allowed:
+		return errorf(n, "dateOfSession is only allowed in the first step of a sequence")
+	case !first:
+		return errorf(n, "dateOfSession is only allowed as the first condition of a segment")
+	case ored:
+		return errorf(n, "dateOfSession cannot be combined with other expressions by OR")
+	}
+	if _, err := e.DateOfSession(); err != nil {
+		return errorf(n, "%v", err)
+	}
+	return nil
+}
+
+// andExpression reads the `all:` or `any:` list of a mapping and passes the other keys to other.
+func andExpression(n *yaml.Node, dos *dateOfSession, other func(key, value *yaml.Node) error) (gasegment.AndExpression, error) {
+	var ae gasegment.AndExpression
+	var found *yaml.Node
+	err := mapping(n, func(key, value *yaml.Node) error {
+		if key.Value != "all" && key.Value != "any" {
+			return other(key, value)
+		}
+		if found != nil {
+			return errorf(key, "both %s and %s", found.Value, key.Value)
+		}
+		found = key
+		if key.Value == "any" {
+			or, err := orExpression(value, dos, true)
+			ae = gasegment.AndExpression{or}
+			return err
+		}
+		ae = gasegment.AndExpression{}
+		err := sequence(value, func(n *yaml.Node) error {
+			if n.Kind == yaml.MappingNode && len(n.Content) == 2 && n.Content[0].Value == "any" {
+				or, err := orExpression(n.Content[1], dos, len(ae) == 0)
+				ae = append(ae, or)
+				return err
+			}
+			e, err := expression(n)
+			if err == nil {
+				err = dos.check(n, e, len(ae) == 0, false)
+			}
+			ae = append(ae, gasegment.OrExpression{e})
+			return err
+		})
+		if err == nil && len(ae) == 0 {
+			err = errorf(value, "empty all")
+		}
+		return err
+	})
+	if err == nil && found == nil {
+		err = errorf(n, "no all or any")
+	}
+	return ae, err
+}
+
+func orExpression(n *yaml.Node, dos *dateOfSession, first bool) (gasegment.OrExpression, error) {
+	or := gasegment.OrExpression{}
+	err := sequence(n, func(n *yaml.Node) error {
+		e, err := expression(n)
+		or = append(or, e)
+		return err
+	})
+	if err == nil && len(or) == 0 {
+		err = errorf(n, "empty any")
+	}
+	for i := 0; err == nil && i < len(or); i++ {
+		err = dos.check(n.Content[i], or[i], first, len(or) > 1)
+	}
+	return or, err
+}
+
+func expression(n *yaml.Node) (gasegment.Expression, error) {
+	var e gasegment.Expression
+	var value, min, max *string
+	var values []string
+	var hasValues bool
+	err := mapping(n, func(key, v *yaml.Node) error {
+		var s string
+		var err error
+		if key.Value != "values" {
+			if s, err = str(v); err != nil {
+				return err
+			}
+		}
+		switch key.Value {
+		case "metricScope":
+			ms, ok := metricScopes[s]
+			if !ok {
+				return errorf(v, "unknown metric scope %q", s)
+			}
+			e.MetricScope = ms
+		case "target":
+			e.Target = gasegment.DimensionOrMetric(s)
+		case "operator":
+			if !operators[gasegment.Operator(s)] {
+				return errorf(v, "unknown operator %q", s)
+			}
+			e.Operator = gasegment.Operator(s)
+		case "value":
+			value = &s
+		case "min":
+			min = &s
+		case "max":
+			max = &s
+		case "values":
+			hasValues = true
+			return sequence(v, func(n *yaml.Node) error {
+				s, err := str(n)
+				values = append(values, s)
+				return err
+			})
+		default:
+			return errorf(key, "unknown key %q", key.Value)
+		}
+		return nil
+	})
+	switch {
+	case err != nil:
+		return e, err
+	case e.Target == "":
+		return e, errorf(n, "expression has no target")
+	case e.Operator == "":
+		return e, errorf(n, "expression has no operator")
+	case value != nil:
+		e.Value = *value
+	case e.IsList() && hasValues:
+		e.Value = gasegment.JoinListValue(values)
+	case e.IsRange() && min != nil && max != nil:
+		e.Value = gasegment.JoinRangeValue(*min, *max)
+	case e.IsList():
+		return e, errorf(n, "%s%s has no values", e.Target, e.Operator)
+	case e.IsRange():
+		return e, errorf(n, "%s%s has no min and max", e.Target, e.Operator)
+	default:
+		return e, errorf(n, "%s%s has no value", e.Target, e.Operator)
+	}
+	// as Segments.Validate checks it
+	if err := gasegment.ValidateExpression(e); err != nil {
+		return e, errorf(n, "%s: %v", e.Target, err)
+	}
+	return e, nil
+}
+
+func mapping(n *yaml.Node, fn func(key, value *yaml.Node) error) error {
+	if n.Kind != yaml.MappingNode {
+		return errorf(n, "expected a mapping")
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if err := fn(n.Content[i], n.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// single returns the only key and value of a mapping.
+func single(n *yaml.Node, expected string) (key, value *yaml.Node, err error) {
+	if n.Kind != yaml.MappingNode || len(n.Content) != 2 {
+		return nil, nil, errorf(n, "expected a mapping with one of %s", expected)
+	}
+	return n.Content[0], n.Content[1], nil
+}
+
+func sequence(n *yaml.Node, fn func(n *yaml.Node) error) error {
+	if n.Kind != yaml.SequenceNode {
+		return errorf(n, "expected a list")
+	}
+	for _, c := range n.Content {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// str returns a scalar as is, so that `value: 1.50` keeps its zero.
+func str(n *yaml.Node) (string, error) {
+	if n.Kind != yaml.ScalarNode {
+		return "", errorf(n, "expected a string")
+	}
+	if n.Tag == "!!null" {
+		return "", nil
+	}
+	return n.Value, nil
+}
+
+func boolean(n *yaml.Node) (bool, error) {
+	var b bool
+	if n.Kind != yaml.ScalarNode || n.Decode(&b) != nil {
+		return false, errorf(n, "expected true or false")
+	}
+	return b, nil
+}
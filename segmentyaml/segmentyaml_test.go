@@ -0,0 +1,171 @@
+package segmentyaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wacul/gasegment"
+)
+
+const library = `segments:
+  - name: Buyers from search
+    description: Users who bought after a search, on mobile
+    definition:
+      - users:
+          condition:
+            all:
+              - {target: ga:transactions, metricScope: perUser, operator: ">", value: "0"}
+              - any:
+                  - {target: ga:deviceCategory, operator: "==", value: mobile}
+                  - {target: ga:deviceCategory, operator: "[]", values: [tablet, "a|b"]}
+      - sessions:
+          sequence:
+            steps:
+              - all:
+                  - {target: 'ga:pagePath', operator: "=~", value: ^/search}
+              - immediately: true
+                any:
+                  - {target: 'ga:pagePath', operator: "==", value: /cart;a}
+                  - {target: ga:hits, operator: "<>", min: 1, max: 1.50}
+      - condition:
+          exclude: true
+          all:
+            - {target: ga:browser, operator: "!=", value: ~}
+  - name: All users
+    definition:
+      - gaid: "-1"
+`
+
+func TestLoad(t *testing.T) {
+	defs, err := Load([]byte(library))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`users::condition::perUser::ga:transactions>0;ga:deviceCategory==mobile,ga:deviceCategory[]tablet|a\|b;sessions::sequence::ga:pagePath=~^/search;->ga:pagePath==/cart\;a,ga:hits<>1_1.50;condition::!ga:browser!=`,
+		"gaid::-1",
+	}
+	if len(defs) != len(expected) {
+		t.Fatalf("unexpected %#v", defs)
+	}
+	for i, def := range defs {
+		if act := def.Segments.DefString(); act != expected[i] {
+			t.Errorf("expected: %s\n\tactual:   %s", expected[i], act)
+		}
+	}
+	if defs[0].Name != "Buyers from search" || defs[0].Description != "Users who bought after a search, on mobile" {
+		t.Errorf("unexpected %#v", defs[0])
+	}
+}
+
+func TestDumpRoundTrip(t *testing.T) {
+	defs, err := Load([]byte(library))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Dump(defs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	act, err := Load(b)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, b)
+	}
+	if len(act) != len(defs) {
+		t.Fatalf("unexpected %#v", act)
+	}
+	for i := range defs {
+		if act[i].Name != defs[i].Name || act[i].Description != defs[i].Description || !act[i].Segments.Equal(defs[i].Segments) {
+			t.Errorf("expected: %#v\n\tactual:   %#v", defs[i], act[i])
+		}
+	}
+
+	for _, def := range []string{
+		"users::condition::ga:pagePath==/a",
+		`sessions::sequence::!^ga:pagePath==null;->>perHit::ga:hits>=true,ga:eventLabel!~\Q:\E;->ga:pagePath![]~|`,
+		`users::condition::ga:pagePath=@'a\,b';ga:sessions!<>1_2;condition::dateOfSession<>2014-05-20_2014-05-30`,
+		"users::sequence::ga:pagePath==/a;sessions::sequence::ga:pagePath==/b;condition::ga:pagePath==",
+	} {
+		ss := gasegment.MustParse(def)
+		b, err := DumpSegments(ss)
+		if err != nil {
+			t.Errorf("%s: %v", def, err)
+			continue
+		}
+		act, err := LoadSegments(b)
+		if err != nil {
+			t.Errorf("%s: %v\n%s", def, err, b)
+			continue
+		}
+		if !act.Equal(ss) || act.DefString() != def {
+			t.Errorf("%s: round trip is %s\n%s", def, act.DefString(), b)
+		}
+	}
+}
+
+func TestDumpSegments(t *testing.T) {
+	b, err := DumpSegments(gasegment.MustParse("users::condition::!ga:pagePath==/a,ga:pagePath==/b;ga:hits>1;sessions::sequence::ga:pagePath=@x;->ga:pagePath<>/a_/b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `- users:
+    condition:
+      exclude: true
+      all:
+        - any:
+            - {target: 'ga:pagePath', operator: ==, value: /a}
+            - {target: 'ga:pagePath', operator: ==, value: /b}
+        - {target: 'ga:hits', operator: '>', value: "1"}
+- sessions:
+    sequence:
+      steps:
+        - all:
+            - {target: 'ga:pagePath', operator: =@, value: x}
+        - immediately: true
+          all:
+            - {target: 'ga:pagePath', operator: <>, min: /a, max: /b}
+`
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\nactual:\n%s", expected, b)
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	table := []struct {
+		library  bool
+		in       string
+		expected *Error
+	}{
+		{false, "", &Error{1, 1, "empty document"}},
+		{true, "segments:\n  - definition: []\n", &Error{2, 5, "definition has no name"}},
+		{true, "segments:\n  - name: a\n    definition: []\n  - name: a\n    definition: []\n", &Error{4, 5, `duplicate name "a"`}},
+		{false, "- hits:\n    condition: {}\n", &Error{1, 3, `unknown key "hits", expected users, sessions, condition, sequence or gaid`}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: ga:a, operator: '~', value: a}\n", &Error{4, 36, `unknown operator "~"`}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: ga:a, operator: '<>', min: 1}\n", &Error{4, 11, "ga:a<> has no min and max"}},
+		{false, "- users:\n    condition:\n      exclude: maybe\n      all:\n        - {target: ga:pagePath, operator: ==, value: /a}\n", &Error{3, 16, "expected true or false"}},
+		{false, "- users:\n    condition:\n      exclude: true\n", &Error{3, 7, "no all or any"}},
+		{false, "- users:\n    sequence:\n      steps:\n        - immediately: true\n          all:\n            - {target: ga:pagePath, operator: ==, value: /a}\n", &Error{4, 11, "the first step cannot immediately follow another step"}},
+		{false, "- users:\n    sequence:\n      steps:\n        - all:\n            - {target: ga:a, operator: ==, value: a, scope: x}\n", &Error{5, 54, `unknown key "scope"`}},
+		{false, "- users:\n    condition:\n      all: []\n", &Error{3, 12, "empty all"}},
+		{false, "- users:\n    condition:\n      all:\n        - any: []\n", &Error{4, 16, "empty any"}},
+		{false, "- users:\n    sequence:\n      steps: []\n", &Error{3, 7, "sequence has no steps"}},
+		{false, "- users:\n    sequence:\n      steps:\n        - all:\n            - {target: ga:pagePath, operator: ==, value: /a}\n        - all:\n            - {target: dateOfSession, operator: <>, min: 2014-05-20, max: 2014-05-30}\n", &Error{7, 15, "dateOfSession is only allowed in the first step of a sequence"}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: dateOfSession, operator: <>, min: 2014-05-20, max: 2014-05-30}\n        - {target: dateOfSession, operator: <>, min: 2014-05-20, max: 2014-05-30}\n", &Error{5, 11, "dateOfSession is only allowed as the first condition of a segment"}},
+		{false, "- users:\n    condition:\n      any:\n        - {target: ga:pagePath, operator: ==, value: /a}\n        - {target: dateOfSession, operator: <>, min: 2014-05-20, max: 2014-05-30}\n", &Error{5, 11, "dateOfSession cannot be combined with other expressions by OR"}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: ga:foo bar, operator: ==, value: a}\n", &Error{4, 11, "ga:foo bar: no such dimension or metric"}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: ga:sessions, operator: '>', value: 1, metricScope: perProduct}\n", &Error{4, 11, "ga:sessions: metric ga:sessions is not product scoped"}},
+		{false, "- condition:\n    all:\n      - {target: ga:pagePath, operator: ==, value: /a}\n", &Error{1, 3, "no segment scope (user:: or session::)"}},
+		{false, "- users:\n    condition:\n      all:\n        - {target: ga:pagePath, operator: ==, value: /a}\n- gaid: '-1'\n", &Error{5, 3, "gaid:: segment cannot be combined with other segments"}},
+	}
+	for _, c := range table {
+		var err error
+		if c.library {
+			_, err = Load([]byte(c.in))
+		} else {
+			_, err = LoadSegments([]byte(c.in))
+		}
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("%s: expected %v, but %v", c.in, c.expected, err)
+		}
+	}
+}
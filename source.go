@@ -0,0 +1,175 @@
+package gasegment
+
+import (
+	"errors"
+	"strings"
+)
+
+// Span is a byte range [Start, End) of a definition.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Source records where a node was parsed from.
+//
+// Span covers the whole node. Body covers the part after the scope for a Segment,
+// the part after the step separator for a SequenceStep, and the value for an Expression.
+type Source struct {
+	Span
+	Body Span
+
+	def string // DefString of the node as parsed
+}
+
+var ErrSourceMismatch = errors.New("source positions do not match the definition")
+
+// Patch serializes segments parsed from src with ParseOptions.RecordSource, keeping the
+// original text of every node which has not been changed since parsing. Unlike
+// Segments.DefString, segments keep their order and unchanged nodes keep their escapes
+// and whitespace, so only the edited parts of src differ in the result.
+// Nodes without Source (e.g. added after parsing) are serialized with DefString.
+func Patch(src string, segments Segments) (string, error) {
+	pt := patcher{src: src}
+	buf := make([]string, 0, len(segments))
+	var prevScope SegmentScope
+	for i, sg := range segments {
+		s, err := pt.segment(sg, i == 0 || sg.Scope != prevScope)
+		if err != nil {
+			return "", err
+		}
+		buf = append(buf, s)
+		prevScope = sg.Scope
+	}
+	return strings.Join(buf, ";"), nil
+}
+
+type patcher struct {
+	src string
+}
+
+func (pt patcher) text(s *Source, sp Span) (string, error) {
+	if sp.Start < s.Start || sp.End > s.End || s.Start < 0 || s.End > len(pt.src) {
+		return "", ErrSourceMismatch
+	}
+	return pt.src[sp.Start:sp.End], nil
+}
+
+func (pt patcher) segment(sg Segment, needScope bool) (string, error) {
+	scope := ""
+	if sg.Source != nil {
+		// keep an explicit scope as written
+		written, err := pt.text(sg.Source, Span{sg.Source.Start, sg.Source.Body.Start})
+		if err != nil {
+			return "", err
+		}
+		if written != "" && written == sg.Scope.String() {
+			needScope = true
+		}
+	}
+	if needScope {
+		scope = sg.Scope.String()
+	}
+
+	if sg.Source != nil && sg.DefStringWithoutScope() == sg.Source.def {
+		body, err := pt.text(sg.Source, sg.Source.Body)
+		if err != nil {
+			return "", err
+		}
+		return scope + body, nil
+	}
+
+	switch sg.Type {
+	case ConditionSegment:
+		buf := []string{scope, sg.Type.String()}
+		if sg.Condition.Exclude {
+			buf = append(buf, "!")
+		}
+		ae, err := pt.andExpression(sg.Condition.Groups())
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(append(buf, ae), ""), nil
+	case SequenceSegment:
+		buf := []string{scope, sg.Type.String()}
+		if sg.Sequence.Not {
+			buf = append(buf, "!")
+		}
+		if sg.Sequence.FirstHitMatchesFirstStep {
+			buf = append(buf, "^")
+		}
+		for _, step := range sg.Sequence.SequenceSteps {
+			s, err := pt.sequenceStep(step)
+			if err != nil {
+				return "", err
+			}
+			buf = append(buf, s)
+		}
+		return strings.Join(buf, ""), nil
+	default:
+		return scope + sg.DefStringWithoutScope(), nil
+	}
+}
+
+func (pt patcher) sequenceStep(step SequenceStep) (string, error) {
+	if step.Source != nil && step.DefString() == step.Source.def {
+		return pt.text(step.Source, step.Source.Span)
+	}
+	ae, err := pt.andExpression(step.Groups())
+	if err != nil {
+		return "", err
+	}
+	return step.Type.String() + ae, nil
+}
+
+func (pt patcher) andExpression(ae AndExpression) (string, error) {
+	var buf []string
+	var prev *Source
+	for i, or := range ae {
+		for j, e := range or {
+			if i > 0 || j > 0 {
+				sep := ","
+				if j == 0 {
+					sep = ";"
+				}
+				buf = append(buf, pt.separator(prev, e.Source, sep))
+			}
+			s, err := pt.expression(e)
+			if err != nil {
+				return "", err
+			}
+			buf = append(buf, s)
+			prev = e.Source
+		}
+	}
+	return strings.Join(buf, ""), nil
+}
+
+// separator returns the original text between two adjacent nodes if it is the expected separator.
+func (pt patcher) separator(prev, next *Source, sep string) string {
+	if prev == nil || next == nil || prev.End > next.Start || next.Start > len(pt.src) {
+		return sep
+	}
+	if s := pt.src[prev.End:next.Start]; strings.TrimSpace(s) == sep {
+		return s
+	}
+	return sep
+}
+
+func (pt patcher) expression(e Expression) (string, error) {
+	if e.Source == nil {
+		return e.DefString(), nil
+	}
+	if e.DefString() == e.Source.def {
+		return pt.text(e.Source, e.Source.Span)
+	}
+	// only the value was changed
+	prefix, err := pt.text(e.Source, Span{e.Source.Start, e.Source.Body.Start})
+	if err != nil {
+		return "", err
+	}
+	if prefix == e.MetricScope.String()+e.Target.String()+e.Operator.String() {
+		return prefix + e.EscapedValue(), nil
+	}
+	return e.DefString(), nil
+}
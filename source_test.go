@@ -0,0 +1,109 @@
+package gasegment
+
+import "testing"
+
+func TestRecordSource(t *testing.T) {
+	src := `sessions::condition::ga:pagePath==/a\,b;perHit::ga:hits>1;users::sequence::ga:pagePath==/c;->>ga:pagePath==/d`
+	ss, err := ParseWithOptions(src, ParseOptions{RecordSource: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := func(s *Source) string { return src[s.Start:s.End] }
+	body := func(s *Source) string { return src[s.Body.Start:s.Body.End] }
+	checks := []struct{ actual, expected string }{
+		{text(ss[0].Source), `sessions::condition::ga:pagePath==/a\,b;perHit::ga:hits>1`},
+		{body(ss[0].Source), `condition::ga:pagePath==/a\,b;perHit::ga:hits>1`},
+		{text(ss[0].Condition.AndExpression[0][0].Source), `ga:pagePath==/a\,b`},
+		{body(ss[0].Condition.AndExpression[0][0].Source), `/a\,b`},
+		{text(ss[0].Condition.AndExpression[1][0].Source), `perHit::ga:hits>1`},
+		{text(ss[1].Sequence.SequenceSteps[0].Source), `ga:pagePath==/c`},
+		{text(ss[1].Sequence.SequenceSteps[1].Source), `;->>ga:pagePath==/d`},
+		{body(ss[1].Sequence.SequenceSteps[1].Source), `ga:pagePath==/d`},
+	}
+	for _, c := range checks {
+		if c.actual != c.expected {
+			t.Errorf("unexpected source %q, expected %q", c.actual, c.expected)
+		}
+	}
+
+	if plain := MustParse(src); plain[0].Source != nil || plain[0].Condition.AndExpression[0][0].Source != nil {
+		t.Error("Parse must not record source")
+	}
+}
+
+func TestPatch(t *testing.T) {
+	table := []struct {
+		src      string
+		edit     func(Segments) Segments
+		expected string
+	}{
+		{
+			// unchanged: keeps order, escapes and whitespace
+			src:      "users::condition::ga:pagePath==/a ;  ga:pagePath=~^\\Qa\\E;sessions::condition::ga:hits>1;users::condition::ga:hits>2",
+			edit:     func(ss Segments) Segments { return ss },
+			expected: "users::condition::ga:pagePath==/a ;  ga:pagePath=~^\\Qa\\E;sessions::condition::ga:hits>1;users::condition::ga:hits>2",
+		},
+		{
+			// change a value only
+			src: "users::condition::ga:pagePath==/a ;  ga:pagePath==/b;sessions::condition::ga:hits>1;condition::ga:hits>2",
+			edit: func(ss Segments) Segments {
+				ss[0].Condition.AndExpression[1][0].Value = "/b;c"
+				return ss
+			},
+			expected: "users::condition::ga:pagePath==/a ;  ga:pagePath==/b\\;c;sessions::condition::ga:hits>1;condition::ga:hits>2",
+		},
+		{
+			// change an operator and add an expression
+			src: "sessions::sequence::^ga:pagePath==/a;->>ga:hits>1;->ga:pagePath==/c",
+			edit: func(ss Segments) Segments {
+				ss[0].Sequence.SequenceSteps[1].AndExpression[0][0].Operator = GreaterThanEqual
+				ss[0].Sequence.SequenceSteps[2].AndExpression[0] = append(ss[0].Sequence.SequenceSteps[2].AndExpression[0], Expression{
+					Target:   "ga:pagePath",
+					Operator: Equal,
+					Value:    "/d",
+				})
+				return ss
+			},
+			expected: "sessions::sequence::^ga:pagePath==/a;->>ga:hits>=1;->ga:pagePath==/c,ga:pagePath==/d",
+		},
+		{
+			// change a scope of inherited segment
+			src: "sessions::condition::ga:hits>1;condition::ga:hits>2;condition::ga:hits>3",
+			edit: func(ss Segments) Segments {
+				ss[1].Scope = UserScope
+				return ss
+			},
+			expected: "sessions::condition::ga:hits>1;users::condition::ga:hits>2;sessions::condition::ga:hits>3",
+		},
+		{
+			// drop a segment
+			src: "sessions::condition::ga:hits>1;users::condition::ga:hits>2",
+			edit: func(ss Segments) Segments {
+				return ss[1:]
+			},
+			expected: "users::condition::ga:hits>2",
+		},
+	}
+
+	for _, c := range table {
+		ss, err := ParseWithOptions(c.src, ParseOptions{RecordSource: true, AllowWhitespace: true})
+		if err != nil {
+			t.Errorf("%s: %s", c.src, err)
+			continue
+		}
+		act, err := Patch(c.src, c.edit(ss))
+		if err != nil {
+			t.Errorf("%s: %s", c.src, err)
+			continue
+		}
+		if act != c.expected {
+			t.Errorf("patch failed\n\texpected: %s\n\tactual:   %s", c.expected, act)
+		}
+	}
+
+	ss, _ := ParseWithOptions("users::condition::ga:hits>1", ParseOptions{RecordSource: true})
+	if _, err := Patch("users::condition::", ss); err != ErrSourceMismatch {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -9,12 +9,12 @@ package gasegment
 
 Segment
   Scope: SegmentScope{UserScope, SessionScope}
-  Type: SegmentType{ConditionSegment, SequenceSegment}
+  Type: SegmentType{ConditionSegment, SequenceSegment, ReferenceSegment}
   Condition:
     Exclude: bool{true/false}
     AndExpression as OrExpression[]:
       Expression[]
-        * MetricScope: MetricScope{Default,PerHit,PerSession,PerUser}
+        * MetricScope: MetricScope{Default,PerHit,PerSession,PerUser,PerProduct}
         Target: DimensionOrMetric{}
         Operator: Operator{Equal,NotEqual,LessThan,LessThanEqual,GreaterThan,GreaterThanEqual,Between,InList,ContainsSubstring,NotContainsSubstring,Regexp,NotRegexp}
         Value: string{}
@@ -25,10 +25,12 @@ Segment
       Type: SequenceStepType{FirstStep,Precedes,ImmediatelyPrecedes}
       AndExpression as OrExpression[]:
         Expression[]
-          MetricScope: MetricScope{Default,PerHit,PerSession,PerUser}
+          MetricScope: MetricScope{Default,PerHit,PerSession,PerUser,PerProduct}
           Target: DimensionOrMetric{}
           Operator: Operator{Equal,NotEqual,LessThan,LessThanEqual,GreaterThan,GreaterThanEqual,Between,InList,ContainsSubstring,NotContainsSubstring,Regexp,NotRegexp}
           Value: string{}
+  Reference:
+    ID: string{} // gaid::{ID} -> Segment.SegmentId
 
 # analyticsreporting
 
@@ -49,7 +49,6 @@ const (
 
 // DetectScope : MetricScope -> scope string
 func DetectScope(metricScope gasegment.MetricScope) (string, error) {
-	// FIXME: it is not found, in gaesegment, that the expression about PerProduct
 	switch metricScope {
 	case gasegment.Default:
 		return ScopeUnspecified, nil
@@ -59,6 +58,8 @@ func DetectScope(metricScope gasegment.MetricScope) (string, error) {
 		return ScopeSession, nil
 	case gasegment.PerUser:
 		return ScopeUser, nil
+	case gasegment.PerProduct:
+		return ScopeProduct, nil
 	default:
 		return "", errors.Errorf("unspecified scope: %v", metricScope)
 	}
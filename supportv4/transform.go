@@ -1,8 +1,6 @@
 package supportv4
 
 import (
-	"strings"
-
 	"github.com/pkg/errors"
 	"github.com/wacul/gasegment"
 	gapi "google.golang.org/api/analyticsreporting/v4"
@@ -21,6 +19,9 @@ func TransformSegments(segments *gasegment.Segments) (*gapi.DynamicSegment, erro
 	var userSegment *gapi.SegmentDefinition
 
 	for _, segment := range segmentSet {
+		if segment.Type == gasegment.ReferenceSegment {
+			return nil, errors.Errorf("cannot transform segment reference %v to dynamic segment", segment.Reference)
+		}
 		switch segment.Scope {
 		case gasegment.UserScope:
 			segmentFilter, err := NewSegmentFilter(&segment)
@@ -56,6 +57,21 @@ func TransformSegments(segments *gasegment.Segments) (*gapi.DynamicSegment, erro
 	}, nil
 }
 
+// TransformToSegment : transform Segments to Segment. a gaid:: reference is transformed to SegmentId, others to DynamicSegment
+func TransformToSegment(segments *gasegment.Segments) (*gapi.Segment, error) {
+	if segments == nil {
+		return nil, nil
+	}
+	if ref, ok := segments.Reference(); ok {
+		return &gapi.Segment{SegmentId: ref.String()}, nil
+	}
+	ds, err := TransformSegments(segments)
+	if err != nil {
+		return nil, err
+	}
+	return &gapi.Segment{DynamicSegment: ds}, nil
+}
+
 // TransformSegment : transform Segument to DynamicSegment
 func TransformSegment(segment *gasegment.Segment) (*gapi.DynamicSegment, error) {
 	if segment == nil {
@@ -157,7 +173,8 @@ func TransformSequqnceStep(step *gasegment.SequenceStep) (*gapi.SegmentSequenceS
 		return nil, nil
 	}
 	matchType, err := DetectMatchType(step.Type)
-	orSegments, err := TransformAndExpression(&step.AndExpression)
+	groups := step.Groups()
+	orSegments, err := TransformAndExpression(&groups)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +189,8 @@ func TransformCondition(condition *gasegment.Condition) (*gapi.SegmentFilter, er
 	if condition == nil {
 		return nil, nil
 	}
-	orSegments, err := TransformAndExpression(&condition.AndExpression)
+	groups := condition.Groups()
+	orSegments, err := TransformAndExpression(&groups)
 	if err != nil {
 		return nil, err
 	}
@@ -249,9 +267,9 @@ func NewDimensionFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterCl
 	switch expr.Operator {
 	case gasegment.Between, gasegment.NotBetween:
 		// between operator "<>{minvalue}_{maxvalue}" (see: https://developers.google.com/analytics/devguides/reporting/core/v3/segments?hl=ja)
-		vs := strings.SplitN(expr.Value, "_", 2)
-		if len(vs) != 2 {
-			return nil, errors.Errorf("required format is '<>{min_value}_{max_value}', but %q", expr.Value)
+		min, max, err := expr.Range()
+		if err != nil {
+			return nil, err
 		}
 		return &gapi.SegmentFilterClause{
 			Not: not,
@@ -259,12 +277,15 @@ func NewDimensionFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterCl
 				// CaseSensitive false, // bool `json:"caseSensitive,omitempty"`
 				DimensionName:      expr.Target.String(),
 				Operator:           op,
-				MinComparisonValue: vs[0],
-				MaxComparisonValue: vs[1],
+				MinComparisonValue: min,
+				MaxComparisonValue: max,
 			},
 		}, nil
 	case gasegment.InList, gasegment.NotInList:
-		vs := parseInListValue(expr.Value)
+		vs, err := expr.List()
+		if err != nil {
+			return nil, err
+		}
 		return &gapi.SegmentFilterClause{
 			Not: not,
 			DimensionFilter: &gapi.SegmentDimensionFilter{
@@ -287,10 +308,6 @@ func NewDimensionFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterCl
 	}
 }
 
-func parseInListValue(v string) []string {
-	return ParseStringWithEscape(v, '|', '\\')
-}
-
 // NewMetricFilterClause : creates filter clause for metric filter
 func NewMetricFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterClause, error) {
 	if expr == nil {
@@ -306,9 +323,9 @@ func NewMetricFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterClaus
 	}
 	if expr.Operator == gasegment.Between || expr.Operator == gasegment.NotBetween {
 		// between operator "<>{minvalue}_{maxvalue}" (see: https://developers.google.com/analytics/devguides/reporting/core/v3/segments?hl=ja)
-		vs := strings.SplitN(expr.Value, "_", 2)
-		if len(vs) != 2 {
-			return nil, errors.Errorf("required format is '<>{min_value}_{max_value}', but %q", expr.Value)
+		min, max, err := expr.Range()
+		if err != nil {
+			return nil, err
 		}
 		return &gapi.SegmentFilterClause{
 			Not: not,
@@ -317,8 +334,8 @@ func NewMetricFilterClause(expr *gasegment.Expression) (*gapi.SegmentFilterClaus
 				// CaseSensitive false, // bool `json:"caseSensitive,omitempty"`
 				MetricName:         expr.Target.String(),
 				Operator:           op,
-				ComparisonValue:    vs[0],
-				MaxComparisonValue: vs[1],
+				ComparisonValue:    min,
+				MaxComparisonValue: max,
 			},
 		}, nil
 	}
@@ -446,3 +446,38 @@ func TestBrokenBetween(t *testing.T) {
 		}
 	})
 }
+
+func TestTransformToSegment(t *testing.T) {
+	segments, err := gasegment.Parse("gaid::-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := TransformToSegment(&segments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SegmentId != "gaid::-3" || s.DynamicSegment != nil {
+		t.Errorf("unexpected segment %v", s)
+	}
+	if v3, err := V3StringifySegment(s); err != nil || v3 != "gaid::-3" {
+		t.Errorf("unexpected v3 string %q, %v", v3, err)
+	}
+	if _, err := TransformSegments(&segments); err == nil {
+		t.Error("must be error")
+	}
+
+	segments, err = gasegment.Parse("sessions::condition::ga:medium==referral")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err = TransformToSegment(&segments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SegmentId != "" || s.DynamicSegment == nil {
+		t.Errorf("unexpected segment %v", s)
+	}
+	if v3, err := V3StringifySegment(s); err != nil || v3 != "sessions::condition::ga:medium==referral" {
+		t.Errorf("unexpected v3 string %q, %v", v3, err)
+	}
+}
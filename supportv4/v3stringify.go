@@ -6,17 +6,24 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/wacul/gasegment"
 	gapi "google.golang.org/api/analyticsreporting/v4"
 )
 
 // V4[AST] -> V3[string]
 
-func escapedJoin(xs []string, sep string, escaped string) string {
-	ys := make([]string, len(xs))
-	for i, x := range xs {
-		ys[i] = strings.Replace(x, sep, escaped, -1)
+// V3StringifySegment :
+func V3StringifySegment(node *gapi.Segment) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+	if node.SegmentId != "" {
+		return node.SegmentId, nil
 	}
-	return strings.Join(ys, sep)
+	if node.DynamicSegment != nil {
+		return V3StringifyDynamicSegment(node.DynamicSegment)
+	}
+	return "", errors.New("at least either a segment id or a dynamic segment")
 }
 
 // V3StringifyDynamicSegment :
@@ -125,9 +132,9 @@ func V3StringifySequenceSegment(node *gapi.SequenceSegment) (string, error) {
 		}
 		if len(inner) > 0 {
 			if isFirst {
-				outer = append(outer, escapedJoin(inner, ";", `\;`))
+				outer = append(outer, strings.Join(inner, ";"))
 			} else {
-				outer = append(outer, bop, escapedJoin(inner, ";", `\;`))
+				outer = append(outer, bop, strings.Join(inner, ";"))
 			}
 		}
 	}
@@ -149,7 +156,7 @@ func V3StringifySimpleSegment(node *gapi.SimpleSegment) (string, error) {
 			outer = append(outer, inner)
 		}
 	}
-	return escapedJoin(outer, ";", `\;`), nil
+	return strings.Join(outer, ";"), nil
 }
 
 // V3StringifyOrFiltersForSegment :
@@ -164,7 +171,7 @@ func V3StringifyOrFiltersForSegment(node *gapi.OrFiltersForSegment) (string, err
 			outer = append(outer, inner)
 		}
 	}
-	return escapedJoin(outer, ",", `\,`), nil
+	return strings.Join(outer, ","), nil
 }
 
 // V3StringifySegmentFilterClause :
@@ -196,34 +203,38 @@ func V3StringifySegmentDimensionFilter(node *gapi.SegmentDimensionFilter, not bo
 		return "", errors.New("invalid expression. at least length >= 1")
 	}
 	// see also: ./detect.go DetectOperatorOnDimension
+	var value string
+	if len(node.Expressions) > 0 {
+		value = gasegment.EscapeExpressionValue(node.Expressions[0])
+	}
 
 	// TODO: node.CaseSensitive
 	switch op {
 	case OperatorRegexp:
 		if not {
-			return fmt.Sprintf("%s!~%s", node.DimensionName, node.Expressions[0]), nil
+			return fmt.Sprintf("%s!~%s", node.DimensionName, value), nil
 		}
-		return fmt.Sprintf("%s=~%s", node.DimensionName, node.Expressions[0]), nil
+		return fmt.Sprintf("%s=~%s", node.DimensionName, value), nil
 	case OperatorBeginsWith:
 		if not {
-			return fmt.Sprintf("%s!~%s", node.DimensionName, "^"+regexp.QuoteMeta(node.Expressions[0])), nil
+			return fmt.Sprintf("%s!~%s", node.DimensionName, gasegment.EscapeExpressionValue("^"+regexp.QuoteMeta(node.Expressions[0]))), nil
 		}
-		return fmt.Sprintf("%s=~%s", node.DimensionName, "^"+regexp.QuoteMeta(node.Expressions[0])), nil
+		return fmt.Sprintf("%s=~%s", node.DimensionName, gasegment.EscapeExpressionValue("^"+regexp.QuoteMeta(node.Expressions[0]))), nil
 	case OperatorEndsWith:
 		if not {
-			return fmt.Sprintf("%s!~%s", node.DimensionName, regexp.QuoteMeta(node.Expressions[0])+"$"), nil
+			return fmt.Sprintf("%s!~%s", node.DimensionName, gasegment.EscapeExpressionValue(regexp.QuoteMeta(node.Expressions[0])+"$")), nil
 		}
-		return fmt.Sprintf("%s=~%s", node.DimensionName, regexp.QuoteMeta(node.Expressions[0])+"$"), nil
+		return fmt.Sprintf("%s=~%s", node.DimensionName, gasegment.EscapeExpressionValue(regexp.QuoteMeta(node.Expressions[0])+"$")), nil
 	case OperatorPartial:
 		if not {
-			return fmt.Sprintf("%s!@%s", node.DimensionName, node.Expressions[0]), nil
+			return fmt.Sprintf("%s!@%s", node.DimensionName, value), nil
 		}
-		return fmt.Sprintf("%s=@%s", node.DimensionName, node.Expressions[0]), nil
+		return fmt.Sprintf("%s=@%s", node.DimensionName, value), nil
 	case OperatorExact, OperatorNumericEquals:
 		if not {
-			return fmt.Sprintf("%s!=%s", node.DimensionName, node.Expressions[0]), nil
+			return fmt.Sprintf("%s!=%s", node.DimensionName, value), nil
 		}
-		return fmt.Sprintf("%s==%s", node.DimensionName, node.Expressions[0]), nil
+		return fmt.Sprintf("%s==%s", node.DimensionName, value), nil
 	case OperatorInList:
 		// TODO: limitation of number of expressions <= 10
 		var op string
@@ -232,17 +243,17 @@ func V3StringifySegmentDimensionFilter(node *gapi.SegmentDimensionFilter, not bo
 		} else {
 			op = "[]"
 		}
-		return fmt.Sprintf("%s%s%s", node.DimensionName, op, escapedJoin(node.Expressions, "|", `\|`)), nil
+		return fmt.Sprintf("%s%s%s", node.DimensionName, op, gasegment.EscapeExpressionValue(gasegment.JoinListValue(node.Expressions))), nil
 	case OperatorNumericLessThan:
 		if not {
-			return fmt.Sprintf("%s>=%s", node.DimensionName, node.Expressions[0]), nil
+			return fmt.Sprintf("%s>=%s", node.DimensionName, value), nil
 		}
-		return fmt.Sprintf("%s<%s", node.DimensionName, node.Expressions[0]), nil
+		return fmt.Sprintf("%s<%s", node.DimensionName, value), nil
 	case OperatorNumericGreaterThan:
 		if not {
-			return fmt.Sprintf("%s<=%s", node.DimensionName, node.Expressions[0]), nil
+			return fmt.Sprintf("%s<=%s", node.DimensionName, value), nil
 		}
-		return fmt.Sprintf("%s>%s", node.DimensionName, node.Expressions[0]), nil
+		return fmt.Sprintf("%s>%s", node.DimensionName, value), nil
 	case OperatorNumericBetween:
 		var op string
 		if not {
@@ -250,9 +261,7 @@ func V3StringifySegmentDimensionFilter(node *gapi.SegmentDimensionFilter, not bo
 		} else {
 			op = "<>"
 		}
-		minValue := strings.Replace(node.MinComparisonValue, "|", `\|`, -1)
-		maxValue := strings.Replace(node.MaxComparisonValue, "|", `\|`, -1)
-		return fmt.Sprintf("%s%s%s_%s", node.DimensionName, op, minValue, maxValue), nil
+		return fmt.Sprintf("%s%s%s", node.DimensionName, op, gasegment.EscapeExpressionValue(gasegment.JoinRangeValue(node.MinComparisonValue, node.MaxComparisonValue))), nil
 	default:
 		return "", errors.Errorf("unsupported dimension operator: %s", op)
 	}
@@ -270,6 +279,7 @@ func V3StringifySegmentMetricFilter(node *gapi.SegmentMetricFilter, not bool) (s
 		op = OperatorEqual
 	}
 	// see also: ./detect.go DetectOperatorOnMetric
+	value := gasegment.EscapeExpressionValue(node.ComparisonValue)
 
 	scopePrefix := ""
 	switch node.Scope {
@@ -285,24 +295,24 @@ func V3StringifySegmentMetricFilter(node *gapi.SegmentMetricFilter, not bool) (s
 	switch op {
 	case OperatorEqual:
 		if not {
-			return fmt.Sprintf("%s%s!=%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+			return fmt.Sprintf("%s%s!=%s", scopePrefix, node.MetricName, value), nil
 		}
-		return fmt.Sprintf("%s%s==%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+		return fmt.Sprintf("%s%s==%s", scopePrefix, node.MetricName, value), nil
 	case OperatorLessThan:
 		if not {
-			return fmt.Sprintf("%s%s>=%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+			return fmt.Sprintf("%s%s>=%s", scopePrefix, node.MetricName, value), nil
 		}
-		return fmt.Sprintf("%s%s<%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+		return fmt.Sprintf("%s%s<%s", scopePrefix, node.MetricName, value), nil
 	case OperatorGreaterThan:
 		if not {
-			return fmt.Sprintf("%s%s<=%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+			return fmt.Sprintf("%s%s<=%s", scopePrefix, node.MetricName, value), nil
 		}
-		return fmt.Sprintf("%s%s>%s", scopePrefix, node.MetricName, node.ComparisonValue), nil
+		return fmt.Sprintf("%s%s>%s", scopePrefix, node.MetricName, value), nil
 	case OperatorBetween:
 		if not {
-			return fmt.Sprintf("%s%s!<>%s_%s", scopePrefix, node.MetricName, node.ComparisonValue, node.MaxComparisonValue), nil
+			return fmt.Sprintf("%s%s!<>%s", scopePrefix, node.MetricName, gasegment.EscapeExpressionValue(gasegment.JoinRangeValue(node.ComparisonValue, node.MaxComparisonValue))), nil
 		}
-		return fmt.Sprintf("%s%s<>%s_%s", scopePrefix, node.MetricName, node.ComparisonValue, node.MaxComparisonValue), nil
+		return fmt.Sprintf("%s%s<>%s", scopePrefix, node.MetricName, gasegment.EscapeExpressionValue(gasegment.JoinRangeValue(node.ComparisonValue, node.MaxComparisonValue))), nil
 	default:
 		return "", errors.Errorf("unsupported metric operator: %s", op)
 	}
@@ -38,7 +38,10 @@ func TestReversible(t *testing.T) {
 		"sessions::condition::ga:deviceCategory=@desktop;condition::ga:pagePath=@embed,ga:pagePath==/files/embed/cartonbox.html,ga:pagePath=@/files/cp/kaitori,ga:pagePath=@/cd/files/kaitori1307,ga:pagePath==/files/selltop.html;ga:pagePath=@sell,ga:pagePath==/files/embed/cartonbox.html,ga:pagePath=@/files/cp/kaitori,ga:pagePath=@/cd/files/kaitori1307,ga:pagePath==/files/selltop.html",
 		"users::sequence::!^ga:pagePath==/aiueo;->ga:pagePath==/aiueo2;->>ga:pagePath==/aiueo3",
 		"users::sequence::!^ga:pagePath==/aiueo;->>ga:pagePath==/aiueo2;->ga:pagePath==/aiueo3",
-		"users::sequence::^ga:sessionCount==1;dateOfSession<>2014-05-20_2014-05-30;->>ga:sessionDurationBucket>600",
+		"users::sequence::^dateOfSession<>2014-05-20_2014-05-30;ga:sessionCount==1;->>ga:sessionDurationBucket>600",
+		"users::condition::dateOfSession<>2014-05-20_2014-05-30;ga:pagePath==/abc",
+		"users::condition::perProduct::ga:itemRevenue>100;perProduct::ga:productDetailViews<>1_10",
+		"sessions::condition::ga:pagePath[]/a\\|b|/c\\;d|/e\\\\\\\\;ga:pagePath==/f\\\\",
 	}
 
 	for i, defstring := range candidates {
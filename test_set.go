@@ -63,7 +63,7 @@ var set = []TestSet{{
 			}), NewOrExpression(Expression{
 				Target:   DimensionOrMetric("ga:pagePath"),
 				Operator: Equal,
-				Value:    "/bcdef;,\\\\",
+				Value:    "/bcdef;,\\",
 			})),
 		},
 	}),
@@ -0,0 +1,133 @@
+package gasegment
+
+import "fmt"
+
+type TokenKind int
+
+const (
+	TokenIllegal TokenKind = iota
+	TokenScope
+	TokenSegmentType
+	TokenSegmentID
+	TokenNot
+	TokenFirstHit
+	TokenMetricScope
+	TokenTarget
+	TokenOperator
+	TokenValue
+	TokenEscape
+	TokenSegmentSeparator
+	TokenStepSeparator
+	TokenAndSeparator
+	TokenOrSeparator
+)
+
+var tokenKindNames = map[TokenKind]string{
+	TokenIllegal:          "illegal",
+	TokenScope:            "scope",
+	TokenSegmentType:      "segment type",
+	TokenSegmentID:        "segment id",
+	TokenNot:              "not",
+	TokenFirstHit:         "first hit",
+	TokenMetricScope:      "metric scope",
+	TokenTarget:           "target",
+	TokenOperator:         "operator",
+	TokenValue:            "value",
+	TokenEscape:           "escape",
+	TokenSegmentSeparator: "segment separator",
+	TokenStepSeparator:    "step separator",
+	TokenAndSeparator:     "and separator",
+	TokenOrSeparator:      "or separator",
+}
+
+func (k TokenKind) String() string {
+	if s, ok := tokenKindNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+var publicTokenKinds = map[tokenKind]TokenKind{
+	tokenIllegal:          TokenIllegal,
+	tokenScope:            TokenScope,
+	tokenSegmentType:      TokenSegmentType,
+	tokenSegmentID:        TokenSegmentID,
+	tokenNot:              TokenNot,
+	tokenFirstHit:         TokenFirstHit,
+	tokenMetricScope:      TokenMetricScope,
+	tokenTarget:           TokenTarget,
+	tokenOperator:         TokenOperator,
+	tokenValue:            TokenValue,
+	tokenSegmentSeparator: TokenSegmentSeparator,
+	tokenStepSeparator:    TokenStepSeparator,
+	tokenAndSeparator:     TokenAndSeparator,
+	tokenOrSeparator:      TokenOrSeparator,
+}
+
+// Token is a lexical element of a segment definition. Text is def[Offset:End].
+type Token struct {
+	Kind   TokenKind
+	Offset int
+	End    int
+	Text   string
+}
+
+// Tokenize splits a definition into tokens with the lexer used by Parse.
+// See ParseOptions.Tokenize.
+func Tokenize(def string) ([]Token, error) {
+	return ParseOptions{}.Tokenize(def)
+}
+
+// Tokenize splits a definition into tokens as the parser with the options sees it.
+//
+// Values are split into TokenValue and TokenEscape (e.g. `\;`, or `\|` in a list) tokens.
+// Whitespace skipped by AllowWhitespace is not covered by any token. Malformed parts are
+// returned as TokenIllegal and tokenizing goes on at the next separator; the error is a
+// *ParseError for the first of them.
+// Errors found only by the parser (e.g. a misplaced dateOfSession) are not reported.
+func (opts ParseOptions) Tokenize(def string) ([]Token, error) {
+	var tokens []Token
+	var err error
+	var op Operator
+	for _, t := range lexWithOptions(def, opts.AllowWhitespace) {
+		switch t.kind {
+		case tokenEOF:
+			continue
+		case tokenOperator:
+			op = Operator(t.text)
+		case tokenValue:
+			tokens = appendValueTokens(tokens, t, op)
+			continue
+		case tokenIllegal:
+			if err == nil {
+				err = newParseError(def, t.pos, t.end, noLocation, t.message, t.expected...)
+			}
+		}
+		tokens = append(tokens, Token{Kind: publicTokenKinds[t.kind], Offset: t.pos, End: t.end, Text: t.text})
+	}
+	return tokens, err
+}
+
+// appendValueTokens appends the value t of the operator split at escape sequences.
+func appendValueTokens(tokens []Token, t token, op Operator) []Token {
+	encoded := valueSeparator(op) != 0
+	start := 0
+	flush := func(end int) {
+		if start < end {
+			tokens = append(tokens, Token{Kind: TokenValue, Offset: t.pos + start, End: t.pos + end, Text: t.text[start:end]})
+		}
+	}
+	for i := 0; i < len(t.text); i++ {
+		if t.text[i] != '\\' || i+1 >= len(t.text) {
+			continue
+		}
+		if encoded || isEscapable(t.text[i+1]) {
+			flush(i)
+			tokens = append(tokens, Token{Kind: TokenEscape, Offset: t.pos + i, End: t.pos + i + 2, Text: t.text[i : i+2]})
+			start = i + 2
+		}
+		i++
+	}
+	flush(len(t.text))
+	return tokens
+}
@@ -0,0 +1,113 @@
+package gasegment
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize(`users::sequence::!^perHit::ga:hits>1;->ga:pagePath==/a\;b\\c,ga:pagePath==/d;sessions::condition::ga:hits>1;ga:pagePath==/a`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var act []string
+	for _, tk := range tokens {
+		act = append(act, tk.Kind.String()+" "+tk.Text)
+	}
+	expected := []string{
+		"scope users::",
+		"segment type sequence::",
+		"not !",
+		"first hit ^",
+		"metric scope perHit::",
+		"target ga:hits",
+		"operator >",
+		"value 1",
+		"step separator ;->",
+		"target ga:pagePath",
+		"operator ==",
+		"value /a",
+		`escape \;`,
+		"value b",
+		`escape \\`,
+		"value c",
+		"or separator ,",
+		"target ga:pagePath",
+		"operator ==",
+		"value /d",
+		"segment separator ;",
+		"scope sessions::",
+		"segment type condition::",
+		"target ga:hits",
+		"operator >",
+		"value 1",
+		"and separator ;",
+		"target ga:pagePath",
+		"operator ==",
+		"value /a",
+	}
+	if !reflect.DeepEqual(act, expected) {
+		t.Errorf("unexpected tokens\n\texpected: %q\n\tactual:   %q", expected, act)
+	}
+}
+
+func TestTokenizeCoversDefinition(t *testing.T) {
+	for _, def := range TestCheckDefs {
+		tokens, err := Tokenize(def)
+		if err != nil {
+			t.Errorf("%s: %s", def, err)
+			continue
+		}
+		var buf []string
+		end := 0
+		for _, tk := range tokens {
+			if tk.Offset != end || def[tk.Offset:tk.End] != tk.Text {
+				t.Errorf("%s: token %v does not follow offset %d", def, tk, end)
+				break
+			}
+			buf = append(buf, tk.Text)
+			end = tk.End
+		}
+		if joined := strings.Join(buf, ""); joined != def {
+			t.Errorf("tokens do not cover the definition\n\texpected: %s\n\tactual:   %s", def, joined)
+		}
+	}
+}
+
+func TestTokenizeIllegal(t *testing.T) {
+	def := "users::condition::ga:pagePath;ga:hits>1;users::unknown::a"
+	tokens, err := Tokenize(def)
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if pe.Offset != 18 || pe.End != 29 {
+		t.Errorf("unexpected error position %d-%d", pe.Offset, pe.End)
+	}
+	var kinds []TokenKind
+	for _, tk := range tokens {
+		kinds = append(kinds, tk.Kind)
+	}
+	expected := []TokenKind{
+		TokenScope, TokenSegmentType, TokenIllegal,
+		TokenAndSeparator, TokenTarget, TokenOperator, TokenValue,
+		TokenAndSeparator, TokenIllegal,
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("unexpected tokens\n\texpected: %v\n\tactual:   %v", expected, kinds)
+	}
+}
+
+func TestTokenizeWhitespace(t *testing.T) {
+	tokens, err := LenientParseOptions.Tokenize("condition::ga:pagePath==/a ; ga:pagePath==/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := tokens[len(tokens)-1]; last.Text != "/b" || last.Offset != 42 {
+		t.Errorf("unexpected token %v", last)
+	}
+	if tokens[4].Kind != TokenAndSeparator || tokens[4].Offset != 27 {
+		t.Errorf("unexpected token %v", tokens[4])
+	}
+}
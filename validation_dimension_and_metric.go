@@ -2,6 +2,7 @@ package gasegment
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ type DimensionOrMetricAttributes struct {
 	PremiumMinTemplateIndex int
 	PremiumMaxTemplateIndex int
 	AllowedInSegments       bool
+	ProductScoped           bool
 
 	pattern *regexp.Regexp
 }
@@ -55,6 +57,40 @@ func (ca *DimensionOrMetricAttributes) Match(dm string) bool {
 var columns analytics.Columns
 var dmDefMap = map[string]DimensionOrMetricAttributes{}
 
+// productScopedMetrics are the metrics which accept perProduct:: scope.
+// The metadata API doesn't expose the scope, so they are listed here. Custom
+// metrics are not listed: their scope is set per property, and callers declare
+// theirs with AddProductScopedMetrics.
+var productScopedMetrics = map[string]bool{
+	"ga:itemQuantity":             true,
+	"ga:uniquePurchases":          true,
+	"ga:itemRevenue":              true,
+	"ga:localItemRevenue":         true,
+	"ga:productAddsToCart":        true,
+	"ga:productCheckouts":         true,
+	"ga:productDetailViews":       true,
+	"ga:productListClicks":        true,
+	"ga:productListViews":         true,
+	"ga:productRefundAmount":      true,
+	"ga:localProductRefundAmount": true,
+	"ga:productRefunds":           true,
+	"ga:productRemovesFromCart":   true,
+	"ga:quantityAddedToCart":      true,
+	"ga:quantityCheckedOut":       true,
+	"ga:quantityRefunded":         true,
+	"ga:quantityRemovedFromCart":  true,
+}
+
+// AddProductScopedMetrics declares custom metrics, e.g. `ga:metric3`, whose
+// scope is set to product in the property, so that ValidateExpression accepts
+// them with perProduct::. It is not safe to call concurrently with validation;
+// call it during initialization.
+func AddProductScopedMetrics(ids ...string) {
+	for _, id := range ids {
+		productScopedMetrics[id] = true
+	}
+}
+
 func convertAttributes(id string, column *analytics.Column) DimensionOrMetricAttributes {
 	ca := DimensionOrMetricAttributes{
 		Id:                id,
@@ -68,6 +104,7 @@ func convertAttributes(id string, column *analytics.Column) DimensionOrMetricAtt
 		Description:       column.Attributes["description"],
 		Calculation:       column.Attributes["calculation"],
 		AllowedInSegments: column.Attributes["allowedInSegments"] == "true",
+		ProductScoped:     productScopedMetrics[id],
 	}
 
 	ca.MinTemplateIndex, _ = strconv.Atoi(column.Attributes["minTemplateIndex"])
@@ -99,7 +136,7 @@ func init() {
 	// dateOfSession
 	{
 		ca := DimensionOrMetricAttributes{
-			Id:                "dateOfSession",
+			Id:                DateOfSession.String(),
 			Type:              "DIMENSION",
 			DataType:          "STRING",
 			Group:             "__special__",
@@ -122,3 +159,51 @@ func GetDimensionOrMetricAttributes(dm string) (DimensionOrMetricAttributes, err
 
 	return DimensionOrMetricAttributes{}, NoSuchDimensionOrMetric
 }
+
+type MetricScopeError string
+
+func (e MetricScopeError) Error() string { return string(e) }
+
+// ValidateExpression checks the target and the metric scope of the expression against the metadata.
+func ValidateExpression(e Expression) error {
+	ca, err := GetDimensionOrMetricAttributes(e.Target.String())
+	if err != nil {
+		return err
+	}
+	if e.MetricScope == Default {
+		return nil
+	}
+	if ca.Type != "METRIC" {
+		return MetricScopeError(fmt.Sprintf("metric scope %s is not allowed on dimension %s", e.MetricScope, e.Target))
+	}
+	// custom metrics share the attributes of their template, e.g. ga:metricXX
+	if e.MetricScope == PerProduct && !ca.ProductScoped && !productScopedMetrics[e.Target.String()] {
+		return MetricScopeError(fmt.Sprintf("metric %s is not product scoped", e.Target))
+	}
+	return nil
+}
+
+// Validate checks every expression in the segments with ValidateExpression.
+func (scs Segments) Validate() error {
+	for _, sg := range scs {
+		var aes []AndExpression
+		switch sg.Type {
+		case ConditionSegment:
+			aes = []AndExpression{sg.Condition.AndExpression}
+		case SequenceSegment:
+			for _, step := range sg.Sequence.SequenceSteps {
+				aes = append(aes, step.AndExpression)
+			}
+		}
+		for _, ae := range aes {
+			for _, or := range ae {
+				for _, e := range or {
+					if err := ValidateExpression(e); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -36,3 +36,53 @@ func TestValidateDimensionOrMetric(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExpression(t *testing.T) {
+	table := []struct {
+		definition string
+		valid      bool
+	}{
+		{"users::condition::perProduct::ga:itemRevenue>100", true},
+		{"users::condition::perUser::ga:itemRevenue>100", true},
+		{"users::condition::perSession::ga:sessions>1;ga:pagePath==/abc", true},
+		{"users::sequence::ga:pagePath==/abc;->>perProduct::ga:productDetailViews>1", true},
+
+		{"users::condition::perProduct::ga:sessions>1", false},
+		{"users::condition::perProduct::ga:metric3>100", false},
+		{"users::condition::perHit::ga:pagePath==/abc", false},
+		{"users::condition::ga:unknownDimension==abc", false},
+	}
+
+	for _, c := range table {
+		ss, err := Parse(c.definition)
+		if err != nil {
+			t.Errorf("%s: %s", c.definition, err)
+			continue
+		}
+		if act := ss.DefString(); act != c.definition {
+			t.Errorf("check failed\n\texpected: %s\n\tactual:   %s", c.definition, act)
+		}
+		err = ss.Validate()
+		if c.valid && err != nil {
+			t.Errorf("unexpected error for %s : %s", c.definition, err.Error())
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s must be invalid", c.definition)
+		}
+	}
+}
+
+func TestAddProductScopedMetrics(t *testing.T) {
+	e := Expression{MetricScope: PerProduct, Target: "ga:metric7", Operator: GreaterThan, Value: "100"}
+	if err := ValidateExpression(e); err == nil {
+		t.Errorf("%s must be invalid", e.DefString())
+	}
+	AddProductScopedMetrics("ga:metric7")
+	defer delete(productScopedMetrics, "ga:metric7")
+	if err := ValidateExpression(e); err != nil {
+		t.Errorf("unexpected error for %s : %s", e.DefString(), err)
+	}
+	if err := ValidateExpression(Expression{MetricScope: PerProduct, Target: "ga:metric3", Operator: GreaterThan, Value: "100"}); err == nil {
+		t.Error("perProduct::ga:metric3 must be invalid")
+	}
+}
@@ -0,0 +1,122 @@
+package gasegment
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ValueError is returned by the typed accessors of Expression when the value
+// does not have the form required by the operator.
+type ValueError struct {
+	Expression Expression
+	Message    string
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Expression.DefString(), e.Message)
+}
+
+func (c Expression) IsRange() bool {
+	return c.Operator == Between || c.Operator == NotBetween
+}
+
+func (c Expression) IsList() bool {
+	return c.Operator == InList || c.Operator == NotInList
+}
+
+// Range returns the bounds of a `<>min_max` or `!<>min_max` expression.
+func (c Expression) Range() (min, max string, err error) {
+	if !c.IsRange() {
+		return "", "", &ValueError{c, fmt.Sprintf("operator %s has no range value", c.Operator)}
+	}
+	vs := SplitRangeValue(c.Value)
+	if len(vs) != 2 {
+		return "", "", &ValueError{c, "required format is '{min_value}_{max_value}'"}
+	}
+	return vs[0], vs[1], nil
+}
+
+// List returns the items of a `[]a|b|c` or `![]a|b|c` expression.
+func (c Expression) List() ([]string, error) {
+	if !c.IsList() {
+		return nil, &ValueError{c, fmt.Sprintf("operator %s has no list value", c.Operator)}
+	}
+	vs := SplitListValue(c.Value)
+	if len(vs) == 0 {
+		return nil, &ValueError{c, "empty list"}
+	}
+	return vs, nil
+}
+
+// Number returns the value of a numeric comparison (==, !=, <, <=, >, >=).
+func (c Expression) Number() (float64, error) {
+	switch c.Operator {
+	case Equal, NotEqual, LessThan, LessThanEqual, GreaterThan, GreaterThanEqual:
+	default:
+		return 0, &ValueError{c, fmt.Sprintf("operator %s is not a numeric comparison", c.Operator)}
+	}
+	return c.parseNumber(c.Value)
+}
+
+// NumberRange returns the bounds of a numeric range.
+func (c Expression) NumberRange() (min, max float64, err error) {
+	smin, smax, err := c.Range()
+	if err != nil {
+		return 0, 0, err
+	}
+	if min, err = c.parseNumber(smin); err != nil {
+		return 0, 0, err
+	}
+	if max, err = c.parseNumber(smax); err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		return 0, 0, &ValueError{c, "min value is greater than max value"}
+	}
+	return min, max, nil
+}
+
+func (c Expression) parseNumber(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, &ValueError{c, fmt.Sprintf("%q is not a number", s)}
+	}
+	return f, nil
+}
+
+// NewBetween creates a `target<>min_max` expression.
+func NewBetween(target DimensionOrMetric, min, max string) Expression {
+	return Expression{
+		Target:   target,
+		Operator: Between,
+		Value:    JoinRangeValue(min, max),
+	}
+}
+
+// NewNumberBetween creates a `target<>min_max` expression with numeric bounds.
+func NewNumberBetween(target DimensionOrMetric, min, max float64) Expression {
+	return NewBetween(target, FormatNumber(min), FormatNumber(max))
+}
+
+// NewInList creates a `target[]a|b|c` expression.
+func NewInList(target DimensionOrMetric, items ...string) Expression {
+	return Expression{
+		Target:   target,
+		Operator: InList,
+		Value:    JoinListValue(items),
+	}
+}
+
+// NewComparison creates a numeric comparison such as `ga:sessions>10`.
+func NewComparison(target DimensionOrMetric, op Operator, v float64) Expression {
+	return Expression{
+		Target:   target,
+		Operator: op,
+		Value:    FormatNumber(v),
+	}
+}
+
+// FormatNumber formats a number as a value of a definition, e.g. `10` or `1.5`.
+func FormatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
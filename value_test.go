@@ -0,0 +1,97 @@
+package gasegment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpressionRange(t *testing.T) {
+	table := []struct {
+		definition string
+		min, max   string
+		ok         bool
+	}{
+		{"ga:hits<>1_10", "1", "10", true},
+		{"ga:hits!<>1_10", "1", "10", true},
+		{`ga:pagePath<>a\_b_c`, "a_b", "c", true},
+		{"ga:hits<>1", "", "", false},
+		{"ga:hits<>1_2_3", "", "", false},
+		{"ga:hits==1_10", "", "", false},
+	}
+	for _, c := range table {
+		e := MustParse("users::condition::" + c.definition)[0].Condition.AndExpression[0][0]
+		min, max, err := e.Range()
+		if !c.ok {
+			if _, ok := err.(*ValueError); !ok {
+				t.Errorf("%s: must be ValueError, but %v", c.definition, err)
+			}
+			continue
+		}
+		if err != nil || min != c.min || max != c.max {
+			t.Errorf("%s: unexpected range %q %q %v", c.definition, min, max, err)
+		}
+	}
+}
+
+func TestExpressionList(t *testing.T) {
+	e := MustParse(`users::condition::ga:pagePath[]/a|/b\|c|/d\;e`)[0].Condition.AndExpression[0][0]
+	items, err := e.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"/a", "/b|c", "/d;e"}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected %q, but %q", expected, items)
+	}
+
+	if _, err := (Expression{Target: "ga:pagePath", Operator: InList}).List(); err == nil {
+		t.Error("empty list must be error")
+	}
+	if _, err := (Expression{Target: "ga:pagePath", Operator: Equal, Value: "a|b"}).List(); err == nil {
+		t.Error("== must be error")
+	}
+}
+
+func TestExpressionNumber(t *testing.T) {
+	if v, err := NewComparison("ga:sessions", GreaterThan, 1.5).Number(); err != nil || v != 1.5 {
+		t.Errorf("unexpected number %v %v", v, err)
+	}
+	if _, err := (Expression{Target: "ga:sessions", Operator: GreaterThan, Value: "many"}).Number(); err == nil {
+		t.Error("non numeric value must be error")
+	}
+	if _, err := (Expression{Target: "ga:pagePath", Operator: Regexp, Value: "1"}).Number(); err == nil {
+		t.Error("=~ must be error")
+	}
+
+	min, max, err := NewNumberBetween("ga:hits", 1, 10).NumberRange()
+	if err != nil || min != 1 || max != 10 {
+		t.Errorf("unexpected range %v %v %v", min, max, err)
+	}
+	if _, _, err := NewNumberBetween("ga:hits", 10, 1).NumberRange(); err == nil {
+		t.Error("reversed range must be error")
+	}
+}
+
+func TestNewExpressionValues(t *testing.T) {
+	table := []struct {
+		expression Expression
+		expected   string
+	}{
+		{NewBetween("ga:pagePath", "a_b", "c;d"), `ga:pagePath<>a\_b_c\;d`},
+		{NewNumberBetween("ga:hits", 1, 2.5), `ga:hits<>1_2.5`},
+		{NewInList("ga:pagePath", "/a", "/b|c", `/d\`), `ga:pagePath[]/a|/b\|c|/d\\\\`},
+		{NewComparison("ga:sessions", LessThanEqual, 100), `ga:sessions<=100`},
+	}
+	for _, c := range table {
+		if act := c.expression.DefString(); act != c.expected {
+			t.Errorf("expected %s, but %s", c.expected, act)
+		}
+		ss, err := Parse("users::condition::" + c.expected)
+		if err != nil {
+			t.Errorf("%s: %s", c.expected, err)
+			continue
+		}
+		if act := ss[0].Condition.AndExpression[0][0]; !reflect.DeepEqual(act, c.expression) {
+			t.Errorf("%s: not reversible %#v", c.expected, act)
+		}
+	}
+}
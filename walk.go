@@ -0,0 +1,153 @@
+package gasegment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of a parsed definition: Segments, Segment, Condition, Sequence,
+// SequenceStep, AndExpression, OrExpression or Expression.
+type Node interface {
+	node()
+}
+
+func (Segments) node()      {}
+func (Segment) node()       {}
+func (Condition) node()     {}
+func (Sequence) node()      {}
+func (SequenceStep) node()  {}
+func (AndExpression) node() {}
+func (OrExpression) node()  {}
+func (Expression) node()    {}
+
+// PathElem is a node on a Path with the way it is reached from its parent.
+type PathElem struct {
+	Node Node
+	// Name is the field the node is reached through, e.g. "condition" or "steps".
+	Name string
+	// Index is the index of the node in its parent, or -1.
+	Index int
+}
+
+// Path is the list of nodes from the root passed to Walk to the visited node.
+type Path []PathElem
+
+// Node returns the visited node.
+func (p Path) Node() Node {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[len(p)-1].Node
+}
+
+// Parent returns the parent of the visited node, or nil for the root.
+func (p Path) Parent() Node {
+	if len(p) < 2 {
+		return nil
+	}
+	return p[len(p)-2].Node
+}
+
+// String returns the path like `segments[0].sequence.steps[1].and[0].or[2]`.
+func (p Path) String() string {
+	var buf []string
+	for _, e := range p {
+		if e.Name != "" {
+			buf = append(buf, "."+e.Name)
+		}
+		if e.Index >= 0 {
+			buf = append(buf, fmt.Sprintf("[%d]", e.Index))
+		}
+	}
+	return strings.TrimPrefix(strings.Join(buf, ""), ".")
+}
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children of node
+// with w, followed by a call of w.Visit(nil, path).
+type Visitor interface {
+	Visit(node Node, path Path) (w Visitor)
+}
+
+// Walk traverses the nodes in depth-first order. The path passed to the visitor
+// is only valid during the call.
+func Walk(node Node, v Visitor) {
+	walk(v, Path{{Node: node, Name: rootName(node), Index: -1}})
+}
+
+func rootName(node Node) string {
+	switch node.(type) {
+	case Segments:
+		return "segments"
+	case Segment:
+		return "segment"
+	case Condition:
+		return "condition"
+	case Sequence:
+		return "sequence"
+	case SequenceStep:
+		return "step"
+	case Expression:
+		return "expression"
+	}
+	return ""
+}
+
+func walk(v Visitor, path Path) {
+	if v = v.Visit(path.Node(), path); v == nil {
+		return
+	}
+	child := func(node Node, name string, index int) {
+		walk(v, append(path, PathElem{Node: node, Name: name, Index: index}))
+	}
+
+	switch n := path.Node().(type) {
+	case Segments:
+		for i, sg := range n {
+			child(sg, "", i)
+		}
+	case Segment:
+		switch n.Type {
+		case ConditionSegment:
+			child(n.Condition, "condition", -1)
+		case SequenceSegment:
+			child(n.Sequence, "sequence", -1)
+		}
+	case Condition:
+		child(n.AndExpression, "", -1)
+	case Sequence:
+		for i, step := range n.SequenceSteps {
+			child(step, "steps", i)
+		}
+	case SequenceStep:
+		child(n.AndExpression, "", -1)
+	case AndExpression:
+		for i, or := range n {
+			child(or, "and", i)
+		}
+	case OrExpression:
+		for i, e := range n {
+			child(e, "or", i)
+		}
+	case Expression:
+	default:
+		panic(fmt.Sprintf("gasegment.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil, path)
+}
+
+type inspector func(Node, Path) bool
+
+func (f inspector) Visit(node Node, path Path) Visitor {
+	if f(node, path) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the nodes in depth-first order, calling f(node, path) for each node.
+// If f returns true, Inspect invokes f for the children of node, followed by f(nil, path).
+func Inspect(node Node, f func(Node, Path) bool) {
+	Walk(node, inspector(f))
+}
@@ -0,0 +1,104 @@
+package gasegment
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	ss := MustParse("users::condition::ga:hits>1,ga:hits<1;sessions::sequence::ga:pagePath==/a;->ga:pagePath==/b")
+
+	var act []string
+	Inspect(ss, func(node Node, path Path) bool {
+		if node == nil {
+			return false
+		}
+		act = append(act, fmt.Sprintf("%T %s", node, path))
+		return true
+	})
+	expected := []string{
+		"gasegment.Segments segments",
+		"gasegment.Segment segments[0]",
+		"gasegment.Condition segments[0].condition",
+		"gasegment.AndExpression segments[0].condition",
+		"gasegment.OrExpression segments[0].condition.and[0]",
+		"gasegment.Expression segments[0].condition.and[0].or[0]",
+		"gasegment.Expression segments[0].condition.and[0].or[1]",
+		"gasegment.Segment segments[1]",
+		"gasegment.Sequence segments[1].sequence",
+		"gasegment.SequenceStep segments[1].sequence.steps[0]",
+		"gasegment.AndExpression segments[1].sequence.steps[0]",
+		"gasegment.OrExpression segments[1].sequence.steps[0].and[0]",
+		"gasegment.Expression segments[1].sequence.steps[0].and[0].or[0]",
+		"gasegment.SequenceStep segments[1].sequence.steps[1]",
+		"gasegment.AndExpression segments[1].sequence.steps[1]",
+		"gasegment.OrExpression segments[1].sequence.steps[1].and[0]",
+		"gasegment.Expression segments[1].sequence.steps[1].and[0].or[0]",
+	}
+	if !reflect.DeepEqual(act, expected) {
+		t.Errorf("unexpected nodes\n\texpected: %q\n\tactual:   %q", expected, act)
+	}
+}
+
+func TestInspectParent(t *testing.T) {
+	ss := MustParse("users::sequence::ga:pagePath==/a;->>ga:pagePath==/b")
+
+	var targets []string
+	Inspect(ss, func(node Node, path Path) bool {
+		switch n := node.(type) {
+		case SequenceStep:
+			// skip the children of the first step
+			return n.Type != FirstStep
+		case Expression:
+			if _, ok := path.Parent().(OrExpression); !ok {
+				t.Errorf("unexpected parent %T", path.Parent())
+			}
+			if _, ok := path[len(path)-4].Node.(SequenceStep); !ok {
+				t.Errorf("unexpected ancestor %T", path[len(path)-4].Node)
+			}
+			targets = append(targets, n.Value)
+		}
+		return node != nil
+	})
+	if !reflect.DeepEqual(targets, []string{"/b"}) {
+		t.Errorf("unexpected expressions %q", targets)
+	}
+}
+
+type countVisitor map[string]int
+
+func (v countVisitor) Visit(node Node, path Path) Visitor {
+	if node == nil {
+		v["leave"]++
+		return nil
+	}
+	v[fmt.Sprintf("%T", node)]++
+	return v
+}
+
+func TestWalk(t *testing.T) {
+	v := countVisitor{}
+	Walk(MustParse("users::condition::ga:hits>1;ga:hits<1,ga:pagePath==/a")[0].Condition, v)
+	expected := countVisitor{
+		"gasegment.Condition":     1,
+		"gasegment.AndExpression": 1,
+		"gasegment.OrExpression":  2,
+		"gasegment.Expression":    3,
+		"leave":                   7,
+	}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected %v, but %v", expected, v)
+	}
+
+	var root Path
+	Inspect(Expression{Target: "ga:hits"}, func(node Node, path Path) bool {
+		if node != nil {
+			root = append(Path(nil), path...)
+		}
+		return true
+	})
+	if root.String() != "expression" || root.Parent() != nil {
+		t.Errorf("unexpected root path %s", root)
+	}
+}